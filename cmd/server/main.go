@@ -18,8 +18,11 @@ import (
 	"time"
 
 	"github.com/carterperez-dev/holophyly/internal/api"
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
 	"github.com/carterperez-dev/holophyly/internal/config"
 	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/jobs"
 	"github.com/carterperez-dev/holophyly/internal/model"
 	"github.com/carterperez-dev/holophyly/internal/project"
 	"github.com/carterperez-dev/holophyly/internal/scanner"
@@ -83,16 +86,15 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 		"address", cfg.Address(),
 	)
 
-	dockerClient, err := docker.NewClient()
+	dockerClients, err := connectDockerHosts(ctx, cfg, logger)
 	if err != nil {
-		return fmt.Errorf("creating docker client: %w", err)
+		return err
 	}
-	defer func() { _ = dockerClient.Close() }()
-
-	if err := dockerClient.Ping(ctx); err != nil {
-		return fmt.Errorf("docker daemon not available: %w", err)
-	}
-	logger.Info("connected to docker daemon")
+	defer func() {
+		for _, client := range dockerClients {
+			_ = client.Close()
+		}
+	}()
 
 	if !docker.IsComposeInstalled(ctx) {
 		logger.Warn("docker compose not found - compose operations will fail")
@@ -112,6 +114,11 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 		logger.Info("preferences store initialized", "path", dataDir)
 	}
 
+	authService, err := setupAuth(cfg, prefStore, logger)
+	if err != nil {
+		return err
+	}
+
 	fileScanner := scanner.NewScanner(cfg.Scanner.Paths, cfg.Scanner.Exclude)
 
 	protection := project.NewProtectionConfig(
@@ -119,7 +126,25 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 		cfg.Protection.Projects,
 	)
 
-	manager := project.NewManager(dockerClient, fileScanner, protection, prefStore)
+	manager := project.NewManager(
+		dockerClients,
+		fileScanner,
+		protection,
+		prefStore,
+	)
+
+	if cfg.Scanner.Watch {
+		err := fileScanner.Watch(ctx, func() {
+			if err := manager.Refresh(ctx); err != nil {
+				logger.Error("rescan after file change failed", "error", err)
+			}
+		})
+		if err != nil {
+			logger.Warn("file watching unavailable - using periodic scans",
+				"error", err,
+			)
+		}
+	}
 
 	if err := manager.Refresh(ctx); err != nil {
 		logger.Warn("initial project scan failed", "error", err)
@@ -131,9 +156,74 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 	hub := websocket.NewHub(logger)
 	go hub.Run(ctx)
 
+	manager.OnProjectChange(func(proj *model.Project) {
+		hub.BroadcastToSubscribers(proj.ID, &websocket.Message{
+			Type:      websocket.MsgProjectStatus,
+			ProjectID: proj.ID,
+			Payload:   proj,
+			Timestamp: time.Now().Unix(),
+		})
+	})
+	manager.OnProjectRemove(func(id string) {
+		hub.BroadcastToSubscribers(id, &websocket.Message{
+			Type:      websocket.MsgProjectRemoved,
+			ProjectID: id,
+			Timestamp: time.Now().Unix(),
+		})
+	})
+	go manager.WatchEvents(ctx)
+
+	jobRunner := jobs.NewRunner(
+		ctx,
+		cfg.Jobs.Workers,
+		cfg.Jobs.Retention,
+		logger,
+	)
+	jobRunner.OnUpdate(func(job *jobs.Job) {
+		hub.BroadcastToPermitted(auth.PermRead, &websocket.Message{
+			Type:      websocket.MsgJobUpdate,
+			ProjectID: job.ProjectID,
+			Payload:   job,
+			Timestamp: time.Now().Unix(),
+		})
+	})
+
+	jobRunner.OnOutput(func(job *jobs.Job, line jobs.Line) {
+		if job.ProjectID == "" {
+			return
+		}
+		hub.BroadcastToSubscribers(job.ProjectID, &websocket.Message{
+			Type:      websocket.MsgComposeOutput,
+			ProjectID: job.ProjectID,
+			Payload: websocket.ComposeOutputPayload{
+				JobID:     job.ID,
+				Kind:      job.Kind,
+				Stream:    line.Stream,
+				Line:      line.Text,
+				Timestamp: line.Timestamp,
+			},
+			Timestamp: time.Now().Unix(),
+		})
+	})
+
+	var auditLog *audit.Log
+	if prefStore != nil {
+		auditLog = audit.New(prefStore)
+		auditLog.OnRecord(func(entry *audit.Entry) {
+			hub.BroadcastToPermitted(auth.PermViewAudit, &websocket.Message{
+				Type:      websocket.MsgAuditEvent,
+				Payload:   entry,
+				Timestamp: time.Now().Unix(),
+			})
+		})
+	}
+
 	router := api.NewRouter(api.RouterConfig{
 		Manager:        manager,
 		Hub:            hub,
+		Jobs:           jobRunner,
+		Auth:           authService,
+		Audit:          auditLog,
 		Logger:         logger,
 		AllowedOrigins: cfg.Server.AllowedOrigins,
 	})
@@ -152,6 +242,15 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 
 	go hub.StartStatsStreamer(ctx, createStatsGetter(manager))
 
+	if cfg.Stats.HistoryEnabled && prefStore != nil {
+		go manager.RecordStatsHistory(ctx, project.HistoryConfig{
+			SampleInterval:  cfg.Stats.SampleInterval,
+			RawRetention:    cfg.Stats.RawRetention,
+			MinuteRetention: cfg.Stats.MinuteRetention,
+			HourRetention:   cfg.Stats.HourRetention,
+		})
+	}
+
 	serverErr := make(chan error, 1)
 	go func() {
 		logger.Info("server listening",
@@ -188,6 +287,102 @@ func run(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
 	return nil
 }
 
+// connectDockerHosts creates a client per configured Docker host.
+// Unreachable hosts are kept so they recover once the daemon comes back,
+// but startup fails if no host is reachable at all.
+func connectDockerHosts(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *slog.Logger,
+) ([]*docker.Client, error) {
+	hosts := cfg.DockerHosts()
+	clients := make([]*docker.Client, 0, len(hosts))
+	seen := make(map[string]bool, len(hosts))
+	reachable := 0
+
+	closeAll := func() {
+		for _, client := range clients {
+			_ = client.Close()
+		}
+	}
+
+	for _, h := range hosts {
+		if h.Name == "" {
+			closeAll()
+			return nil, fmt.Errorf("docker host %q has no name", h.Host)
+		}
+		if seen[h.Name] {
+			closeAll()
+			return nil, fmt.Errorf("duplicate docker host name: %s", h.Name)
+		}
+		seen[h.Name] = true
+
+		client, err := docker.NewClient(docker.Endpoint{
+			Name:      h.Name,
+			Host:      h.Host,
+			TLSCACert: h.TLS.CACert,
+			TLSCert:   h.TLS.Cert,
+			TLSKey:    h.TLS.Key,
+		})
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("creating docker client: %w", err)
+		}
+		clients = append(clients, client)
+
+		if err := client.Ping(ctx); err != nil {
+			logger.Warn("docker host not available",
+				"host", h.Name,
+				"error", err,
+			)
+			continue
+		}
+
+		reachable++
+		logger.Info("connected to docker daemon", "host", h.Name)
+	}
+
+	if reachable == 0 {
+		closeAll()
+		return nil, fmt.Errorf("docker daemon not available on any host")
+	}
+
+	return clients, nil
+}
+
+// setupAuth creates the auth service and, on first run, a bootstrap token.
+// Tokens live in the store, so auth cannot run without it; rather than
+// silently serve an open API, startup fails.
+func setupAuth(
+	cfg *config.Config,
+	prefStore *store.Store,
+	logger *slog.Logger,
+) (*auth.Service, error) {
+	if !cfg.Auth.Enabled {
+		logger.Warn("authentication disabled - API is open to anyone who can reach it")
+		return nil, nil
+	}
+
+	if prefStore == nil {
+		return nil, fmt.Errorf("authentication requires the preferences store")
+	}
+
+	service := auth.NewService(prefStore, cfg.Auth.SessionTTL)
+
+	token, err := service.Bootstrap()
+	if err != nil {
+		return nil, fmt.Errorf("bootstrapping auth: %w", err)
+	}
+	if token != "" {
+		logger.Warn("no API tokens found - created bootstrap token; "+
+			"store it now, it will not be shown again",
+			"token", token,
+		)
+	}
+
+	return service, nil
+}
+
 func setupLogger(level, format string) *slog.Logger {
 	var logLevel slog.Level
 	switch level {
@@ -216,6 +411,10 @@ func setupLogger(level, format string) *slog.Logger {
 	return slog.New(handler)
 }
 
+// runPeriodicScanner rescans compose files and reconciles container state.
+// Live changes arrive through the docker event stream and the file watcher;
+// this catches anything they missed, and walks the scan paths when file
+// watching is unavailable.
 func runPeriodicScanner(
 	ctx context.Context,
 	manager *project.Manager,
@@ -0,0 +1,165 @@
+/*
+AngelaMos | 2026
+audit.go
+*/
+
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// auditEntry fills in the actor and request ID for an entry and marks
+// the start of the action.
+func auditEntry(r *http.Request, entry *audit.Entry) *audit.Entry {
+	if principal := auth.FromContext(r.Context()); principal != nil {
+		entry.ActorID = principal.TokenID
+		entry.Actor = principal.TokenName
+	}
+	entry.RequestID = middleware.GetReqID(r.Context())
+	entry.Time = time.Now()
+	return entry
+}
+
+// recordAudit completes entry with the outcome of the action and stores
+// it. A nil log disables auditing.
+func recordAudit(
+	log *audit.Log,
+	logger *slog.Logger,
+	entry *audit.Entry,
+	result *docker.ComposeResult,
+	err error,
+) {
+	if log == nil {
+		return
+	}
+
+	entry.DurationMS = time.Since(entry.Time).Milliseconds()
+
+	entry.Result = audit.ResultSuccess
+	if err != nil {
+		entry.Result = audit.ResultFailure
+		entry.Error = err.Error()
+	}
+
+	if result != nil {
+		entry.Output = composeOutput(result)
+	}
+
+	if err := log.Record(entry); err != nil {
+		logger.Error("failed to record audit entry",
+			"action", entry.Action,
+			"target", entry.Target,
+			"error", err,
+		)
+	}
+}
+
+// composeOutput joins stdout and stderr; compose reports progress on
+// stderr even when it succeeds.
+func composeOutput(result *docker.ComposeResult) string {
+	var output []string
+	for _, s := range []string{result.Output, result.Error} {
+		if s != "" {
+			output = append(output, s)
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// auditProject starts an audit entry for an action against a project.
+func (h *Handler) auditProject(
+	r *http.Request,
+	action audit.Action,
+	id string,
+	params map[string]any,
+) *audit.Entry {
+	entry := &audit.Entry{
+		Action:     action,
+		TargetType: audit.TargetProject,
+		Target:     id,
+		Params:     params,
+	}
+	if proj, err := h.manager.GetProject(id); err == nil {
+		entry.TargetName = proj.Name
+	}
+
+	return auditEntry(r, entry)
+}
+
+func (h *Handler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	if h.audit == nil {
+		respondError(w, http.StatusServiceUnavailable, "audit log unavailable")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := store.AuditFilter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		Target:     query.Get("target"),
+		Result:     query.Get("result"),
+		Limit:      defaultAuditLimit,
+	}
+
+	if raw := query.Get("since"); raw != "" {
+		since, err := parseTime(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid since")
+			return
+		}
+		filter.Since = since
+	}
+
+	if raw := query.Get("until"); raw != "" {
+		until, err := parseTime(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid until")
+			return
+		}
+		filter.Until = until
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = min(limit, maxAuditLimit)
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	page, err := h.audit.Query(filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, page)
+}
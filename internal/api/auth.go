@@ -0,0 +1,284 @@
+/*
+AngelaMos | 2026
+auth.go
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
+)
+
+const sessionCookie = "holophyly_session"
+
+type AuthHandler struct {
+	auth   *auth.Service
+	audit  *audit.Log
+	logger *slog.Logger
+}
+
+// NewAuthHandler creates handlers for login and token management.
+func NewAuthHandler(
+	service *auth.Service,
+	auditLog *audit.Log,
+	logger *slog.Logger,
+) *AuthHandler {
+	return &AuthHandler{
+		auth:   service,
+		audit:  auditLog,
+		logger: logger,
+	}
+}
+
+// RequireAuth rejects requests without a valid bearer token or session
+// cookie. Browsers cannot set headers on WebSocket upgrades, so the
+// upgrade also accepts an access_token query parameter.
+func RequireAuth(service *auth.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticate(service, r)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="holophyly"`)
+				respondError(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+
+			ctx := auth.WithPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// AnonymousAccess marks requests as coming from the anonymous admin
+// principal. It stands in for RequireAuth when authentication is disabled
+// so permission checks still have a principal to inspect.
+func AnonymousAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.WithPrincipal(r.Context(), auth.Anonymous)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequirePermission rejects principals whose role does not grant perm.
+func RequirePermission(perm auth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorize(w, r, perm) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authorize checks perm for the request principal and writes a 403 with
+// a reason code when it is not granted.
+func authorize(w http.ResponseWriter, r *http.Request, perm auth.Permission) bool {
+	principal := auth.FromContext(r.Context())
+	if principal.Can(perm) {
+		return true
+	}
+
+	role := ""
+	if principal != nil {
+		role = string(principal.Role)
+	}
+
+	respondJSON(w, http.StatusForbidden, map[string]string{
+		"error":         "insufficient role for " + string(perm),
+		"code":          codeInsufficientRole,
+		"permission":    string(perm),
+		"required_role": string(auth.RequiredRole(perm)),
+		"role":          role,
+	})
+	return false
+}
+
+func authenticate(service *auth.Service, r *http.Request) (*auth.Principal, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return nil, auth.ErrInvalidCredentials
+		}
+		return service.AuthenticateToken(strings.TrimSpace(token))
+	}
+
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		return service.AuthenticateSession(cookie.Value)
+	}
+
+	if token := r.URL.Query().Get("access_token"); token != "" &&
+		strings.HasPrefix(r.URL.Path, "/ws/") {
+		return service.AuthenticateToken(token)
+	}
+
+	return nil, auth.ErrInvalidCredentials
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	sessionID, expires, err := h.auth.Login(req.Token)
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidCredentials) {
+			h.logger.Error("failed to create session", "error", err)
+			respondError(w, http.StatusInternalServerError, "login failed")
+			return
+		}
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    sessionID,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"expires_at": expires,
+	})
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		if err := h.auth.Logout(cookie.Value); err != nil {
+			h.logger.Error("failed to delete session", "error", err)
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, auth.FromContext(r.Context()))
+}
+
+func (h *AuthHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.auth.ListTokens()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name      string `json:"name"`
+		Role      string `json:"role"`
+		ExpiresIn string `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	role := auth.RoleViewer
+	if req.Role != "" {
+		parsed, err := auth.ParseRole(req.Role)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		role = parsed
+	}
+
+	var ttl time.Duration
+	if req.ExpiresIn != "" {
+		parsed, err := parseDuration(req.ExpiresIn)
+		if err != nil || parsed <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid expires_in")
+			return
+		}
+		ttl = parsed
+	}
+
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.ActionTokenCreate,
+		TargetType: audit.TargetToken,
+		TargetName: req.Name,
+		Params: map[string]any{
+			"role":       role,
+			"expires_in": req.ExpiresIn,
+		},
+	})
+	plaintext, token, err := h.auth.CreateToken(req.Name, role, ttl)
+	if token != nil {
+		entry.Target = token.ID
+	}
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		h.logger.Error("failed to create token", "error", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The plaintext is only ever returned here.
+	respondJSON(w, http.StatusCreated, map[string]any{
+		"token":   plaintext,
+		"details": token,
+	})
+}
+
+func (h *AuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.ActionTokenRevoke,
+		TargetType: audit.TargetToken,
+		Target:     id,
+	})
+	err := h.auth.RevokeToken(id)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+/*
+AngelaMos | 2026
+containers.go
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/jobs"
+	"github.com/carterperez-dev/holophyly/internal/project"
+)
+
+func (h *Handler) ListContainers(w http.ResponseWriter, r *http.Request) {
+	containers, err := h.manager.ListContainers(
+		r.Context(),
+		r.URL.Query().Get("host"),
+	)
+	if err != nil {
+		respondContainerError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, containers)
+}
+
+func (h *Handler) InspectContainer(w http.ResponseWriter, r *http.Request) {
+	ctr, err := h.manager.InspectContainer(
+		r.Context(),
+		chi.URLParam(r, "id"),
+		r.URL.Query().Get("host"),
+	)
+	if err != nil {
+		respondContainerError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ctr)
+}
+
+// ContainerAction queues a lifecycle action on a single container as a
+// job and responds like project operations do. Query parameters: timeout
+// (seconds) for stop and restart, signal for kill, force to override
+// protection or remove a running container, and host to skip looking the
+// container up on every host.
+func (h *Handler) ContainerAction(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	action := project.ContainerAction(chi.URLParam(r, "action"))
+	query := r.URL.Query()
+	hostName := query.Get("host")
+
+	if !action.Valid() {
+		respondError(w, http.StatusNotFound, "unknown container action")
+		return
+	}
+
+	opts := project.ContainerActionOptions{
+		Signal: query.Get("signal"),
+		Force:  query.Get("force") == "true",
+	}
+
+	if raw := query.Get("timeout"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			respondError(w, http.StatusBadRequest, "invalid timeout")
+			return
+		}
+		opts.Timeout = time.Duration(seconds) * time.Second
+	}
+
+	ctr, err := h.manager.InspectContainer(r.Context(), id, hostName)
+	if err != nil {
+		respondContainerError(w, err)
+		return
+	}
+
+	if action.Guarded() {
+		if protected, _ := h.manager.ContainerProtection(ctr); protected {
+			if !opts.Force {
+				respondErrorCode(w, http.StatusForbidden, codeProjectProtected,
+					"container is protected - use force to override")
+				return
+			}
+			if !authorize(w, r, auth.PermForceStop) {
+				return
+			}
+		}
+	}
+
+	params := map[string]any{}
+	if opts.Force {
+		params["force"] = true
+	}
+	if opts.Timeout > 0 {
+		params["timeout"] = int(opts.Timeout.Seconds())
+	}
+	if opts.Signal != "" {
+		params["signal"] = opts.Signal
+	}
+
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.Action(action),
+		TargetType: audit.TargetContainer,
+		Target:     ctr.ID,
+		TargetName: ctr.Name,
+		Params:     params,
+	})
+
+	// Actions on a project's containers queue behind the project's compose
+	// jobs; other containers are serialized on their own.
+	key := "container:" + ctr.ID
+	if ctr.ProjectID != "" {
+		key = "project:" + ctr.ProjectID
+	}
+
+	job, err := h.jobs.Submit(jobs.Spec{
+		Kind:      "container_" + string(action),
+		ProjectID: ctr.ProjectID,
+		Key:       key,
+		Params:    params,
+		Actor:     entry.Actor,
+		Run: func(ctx context.Context, output *jobs.Output) error {
+			entry.Time = time.Now()
+			updated, err := h.manager.RunContainerAction(
+				ctx,
+				ctr.ID,
+				ctr.Host,
+				action,
+				opts,
+			)
+			recordAudit(h.audit, h.logger, entry, nil, err)
+
+			if err != nil {
+				h.logger.Error("container action failed",
+					"container", id,
+					"action", action,
+					"error", err,
+				)
+				return err
+			}
+
+			if updated == nil {
+				fmt.Fprintf(output, "container %s removed\n", ctr.Name)
+				return nil
+			}
+			fmt.Fprintf(output, "container %s %s\n", updated.Name, updated.State)
+			return nil
+		},
+		OnCancel: func() {
+			recordAudit(h.audit, h.logger, entry, nil, jobs.ErrJobCancelled)
+		},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if acceptsEventStream(r) {
+		h.streamJob(w, r, job)
+		return
+	}
+
+	respondJob(w, job)
+}
+
+func respondContainerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, project.ErrContainerNotFound), docker.IsNotFound(err):
+		respondError(w, http.StatusNotFound, err.Error())
+	case docker.IsConflict(err):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondProjectError(w, err)
+	}
+}
@@ -6,36 +6,72 @@ handlers.go
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/jobs"
 	"github.com/carterperez-dev/holophyly/internal/model"
 	"github.com/carterperez-dev/holophyly/internal/project"
 )
 
 type Handler struct {
 	manager *project.Manager
+	jobs    *jobs.Runner
+	audit   *audit.Log
 	logger  *slog.Logger
 }
 
 // NewHandler creates an API handler with the project manager.
-func NewHandler(manager *project.Manager, logger *slog.Logger) *Handler {
+// Long-running operations are queued on jobRunner. Mutating actions are
+// recorded in auditLog when it is non-nil.
+func NewHandler(
+	manager *project.Manager,
+	jobRunner *jobs.Runner,
+	auditLog *audit.Log,
+	logger *slog.Logger,
+) *Handler {
 	return &Handler{
 		manager: manager,
+		jobs:    jobRunner,
+		audit:   auditLog,
 		logger:  logger,
 	}
 }
 
 func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
-	if err := h.manager.Refresh(r.Context()); err != nil {
-		h.logger.Error("failed to refresh projects", "error", err)
+	// Project state is kept current by the docker event stream; a full
+	// rescan is only done on explicit request.
+	if r.URL.Query().Get("refresh") == "true" {
+		if err := h.manager.Refresh(r.Context()); err != nil {
+			h.logger.Error("failed to refresh projects", "error", err)
+		}
 	}
 
 	projects := h.manager.ListProjects()
+
+	if hostName := r.URL.Query().Get("host"); hostName != "" {
+		filtered := make([]*model.Project, 0, len(projects))
+		for _, proj := range projects {
+			if proj.Host == hostName {
+				filtered = append(filtered, proj)
+			}
+		}
+		projects = filtered
+	}
+
 	respondJSON(w, http.StatusOK, projects)
 }
 
@@ -53,49 +89,152 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) StartProject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	hostName := r.URL.Query().Get("host")
 
-	if err := h.manager.StartProject(r.Context(), id); err != nil {
-		h.logger.Error("failed to start project", "id", id, "error", err)
-		respondError(w, http.StatusInternalServerError, err.Error())
+	if _, ok := h.composeProject(w, id); !ok {
 		return
 	}
 
-	proj, _ := h.manager.GetProject(id)
-	respondJSON(w, http.StatusOK, proj)
+	h.submitProjectJob(w, r, audit.ActionStart, id, map[string]any{
+		"host": hostName,
+	}, func(
+		ctx context.Context,
+		onLine docker.LineFunc,
+	) (*docker.ComposeResult, error) {
+		return h.manager.StartProject(ctx, id, hostName, onLine)
+	})
 }
 
 func (h *Handler) StopProject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	force := r.URL.Query().Get("force") == "true"
 
-	if err := h.manager.StopProject(r.Context(), id, force); err != nil {
-		status := http.StatusInternalServerError
+	proj, ok := h.composeProject(w, id)
+	if !ok {
+		return
+	}
+
+	// Forcing only matters for protected projects, and overriding
+	// protection is an admin decision.
+	if proj.Protected {
 		if !force {
-			proj, _ := h.manager.GetProject(id)
-			if proj != nil && proj.Protected {
-				status = http.StatusForbidden
-			}
+			respondErrorCode(w, http.StatusForbidden, codeProjectProtected,
+				"project is protected - use force to override")
+			return
+		}
+		if !authorize(w, r, auth.PermForceStop) {
+			return
 		}
-		h.logger.Error("failed to stop project", "id", id, "error", err)
-		respondError(w, status, err.Error())
-		return
 	}
 
-	proj, _ := h.manager.GetProject(id)
-	respondJSON(w, http.StatusOK, proj)
+	h.submitProjectJob(w, r, audit.ActionStop, id, map[string]any{
+		"force": force,
+	}, func(
+		ctx context.Context,
+		onLine docker.LineFunc,
+	) (*docker.ComposeResult, error) {
+		return h.manager.StopProject(ctx, id, force, onLine)
+	})
 }
 
 func (h *Handler) RestartProject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.manager.RestartProject(r.Context(), id); err != nil {
-		h.logger.Error("failed to restart project", "id", id, "error", err)
-		respondError(w, http.StatusInternalServerError, err.Error())
+	proj, ok := h.composeProject(w, id)
+	if !ok {
+		return
+	}
+	if proj.Protected {
+		respondErrorCode(w, http.StatusForbidden, codeProjectProtected,
+			"project is protected - cannot restart")
 		return
 	}
 
-	proj, _ := h.manager.GetProject(id)
-	respondJSON(w, http.StatusOK, proj)
+	h.submitProjectJob(w, r, audit.ActionRestart, id, nil, func(
+		ctx context.Context,
+		onLine docker.LineFunc,
+	) (*docker.ComposeResult, error) {
+		return h.manager.RestartProject(ctx, id, onLine)
+	})
+}
+
+func (h *Handler) PullProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	proj, ok := h.composeProject(w, id)
+	if !ok {
+		return
+	}
+	if proj.Source == model.SourceStandalone {
+		respondError(w, http.StatusBadRequest,
+			"standalone containers have no compose file to pull from")
+		return
+	}
+
+	h.submitProjectJob(w, r, audit.ActionPull, id, nil, func(
+		ctx context.Context,
+		onLine docker.LineFunc,
+	) (*docker.ComposeResult, error) {
+		return h.manager.PullProject(ctx, id, onLine)
+	})
+}
+
+// serviceAuditActions maps service actions to their audit action; the
+// service is recorded in the entry's params.
+var serviceAuditActions = map[docker.ServiceAction]audit.Action{
+	docker.ServiceStart:    audit.ActionStart,
+	docker.ServiceStop:     audit.ActionStop,
+	docker.ServiceRestart:  audit.ActionRestart,
+	docker.ServiceRecreate: audit.ActionRecreate,
+	docker.ServicePull:     audit.ActionPull,
+	docker.ServiceBuild:    audit.ActionBuild,
+}
+
+func (h *Handler) ServiceAction(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	service := chi.URLParam(r, "service")
+	action := docker.ServiceAction(chi.URLParam(r, "action"))
+	force := r.URL.Query().Get("force") == "true"
+
+	auditAction, ok := serviceAuditActions[action]
+	if !ok {
+		respondError(w, http.StatusNotFound, "unknown service action")
+		return
+	}
+
+	if _, ok := h.composeProject(w, id); !ok {
+		return
+	}
+
+	protected, _, err := h.manager.ServiceProtection(id, service)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	// As with whole projects, only stop can be forced, and only by admins.
+	if protected && project.IsGuardedServiceAction(action) {
+		if !force || action != docker.ServiceStop {
+			respondErrorCode(w, http.StatusForbidden, codeProjectProtected,
+				"service is protected - only stop can be forced")
+			return
+		}
+		if !authorize(w, r, auth.PermForceStop) {
+			return
+		}
+	}
+
+	params := map[string]any{"service": service}
+	if action == docker.ServiceStop {
+		params["force"] = force
+	}
+
+	h.submitProjectJob(w, r, auditAction, id, params, func(
+		ctx context.Context,
+		onLine docker.LineFunc,
+	) (*docker.ComposeResult, error) {
+		return h.manager.RunServiceAction(ctx, id, service, action, force, onLine)
+	})
 }
 
 func (h *Handler) SetProjectProtection(w http.ResponseWriter, r *http.Request) {
@@ -104,6 +243,7 @@ func (h *Handler) SetProjectProtection(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Protected bool   `json:"protected"`
 		Reason    string `json:"reason,omitempty"`
+		Note      string `json:"note,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -111,13 +251,55 @@ func (h *Handler) SetProjectProtection(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reason := model.ProtectionUserMarked
-	if req.Reason != "" {
-		reason = model.ProtectionReason(req.Reason)
+	switch model.ProtectionReason(req.Reason) {
+	case "",
+		model.ProtectionCloudflareTunnel,
+		model.ProtectionUserMarked,
+		model.ProtectionAutoDetected:
+	default:
+		respondError(w, http.StatusBadRequest, "unknown protection reason")
+		return
 	}
 
-	if err := h.manager.SetProjectProtection(id, req.Protected, reason); err != nil {
-		respondError(w, http.StatusNotFound, err.Error())
+	rule := model.ProtectionRule{
+		Protected: req.Protected,
+		Reason:    model.ProtectionReason(req.Reason),
+		Note:      req.Note,
+	}
+	if principal := auth.FromContext(r.Context()); principal != nil {
+		rule.SetBy = principal.TokenName
+	}
+
+	entry := h.auditProject(r, audit.ActionProtect, id, map[string]any{
+		"protected": req.Protected,
+		"reason":    req.Reason,
+		"note":      req.Note,
+	})
+	err := h.manager.SetProjectProtection(id, rule)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	proj, _ := h.manager.GetProject(id)
+	respondJSON(w, http.StatusOK, proj)
+}
+
+// ClearProjectProtection drops an explicit protect or unprotect decision
+// so automatic detection applies again.
+func (h *Handler) ClearProjectProtection(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	entry := h.auditProject(r, audit.ActionProtect, id, map[string]any{
+		"cleared": true,
+	})
+	err := h.manager.ClearProjectProtection(id)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		respondProjectError(w, err)
 		return
 	}
 
@@ -137,7 +319,13 @@ func (h *Handler) SetProjectDisplayName(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.manager.SetProjectDisplayName(id, req.DisplayName); err != nil {
+	entry := h.auditProject(r, audit.ActionRename, id, map[string]any{
+		"display_name": req.DisplayName,
+	})
+	err := h.manager.SetProjectDisplayName(id, req.DisplayName)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
 		h.logger.Error("failed to set display name", "id", id, "error", err)
 		respondError(w, http.StatusNotFound, err.Error())
 		return
@@ -159,7 +347,13 @@ func (h *Handler) SetProjectHidden(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.manager.SetProjectHidden(id, req.Hidden); err != nil {
+	entry := h.auditProject(r, audit.ActionHide, id, map[string]any{
+		"hidden": req.Hidden,
+	})
+	err := h.manager.SetProjectHidden(id, req.Hidden)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
 		h.logger.Error("failed to set hidden status", "id", id, "error", err)
 		respondError(w, http.StatusNotFound, err.Error())
 		return
@@ -169,6 +363,172 @@ func (h *Handler) SetProjectHidden(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, proj)
 }
 
+// SetComposeFiles chooses the compose files a project is run from, out of
+// its available_files. An empty list restores the default stack.
+func (h *Handler) SetComposeFiles(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req struct {
+		Files []string `json:"files"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	entry := h.auditProject(r, audit.ActionSelectFiles, id, map[string]any{
+		"files": req.Files,
+	})
+	err := h.manager.SetComposeFiles(r.Context(), id, req.Files)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	proj, _ := h.manager.GetProject(id)
+	respondJSON(w, http.StatusOK, proj)
+}
+
+// SetProfiles chooses which of a project's declared profiles are active.
+// An empty list deactivates them all.
+func (h *Handler) SetProfiles(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req struct {
+		Profiles []string `json:"profiles"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	entry := h.auditProject(r, audit.ActionSetProfiles, id, map[string]any{
+		"profiles": req.Profiles,
+	})
+	err := h.manager.SetProfiles(r.Context(), id, req.Profiles)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	proj, _ := h.manager.GetProject(id)
+	respondJSON(w, http.StatusOK, proj)
+}
+
+// GetProjectEnv lists the variables a project's compose files reference,
+// with secret-looking values masked, and those left unset.
+func (h *Handler) GetProjectEnv(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	env, err := h.manager.ProjectEnv(r.Context(), id)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, env)
+}
+
+// SetEnvFiles chooses the env files a project is interpolated with, out of
+// the available_env_files in its directory. An empty list restores .env.
+func (h *Handler) SetEnvFiles(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req struct {
+		Files []string `json:"files"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	entry := h.auditProject(r, audit.ActionSetEnvFiles, id, map[string]any{
+		"files": req.Files,
+	})
+	err := h.manager.SetEnvFiles(r.Context(), id, req.Files)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	proj, _ := h.manager.GetProject(id)
+	respondJSON(w, http.StatusOK, proj)
+}
+
+// GetProjectConfig returns a project's resolved compose model, as JSON or,
+// with format=yaml, as YAML.
+func (h *Handler) GetProjectConfig(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "yaml" {
+		respondError(w, http.StatusBadRequest, "format must be json or yaml")
+		return
+	}
+
+	config, err := h.manager.ProjectConfig(r.Context(), id)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	if format == "yaml" {
+		data, err := config.MarshalYAML()
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+		return
+	}
+
+	data, err := config.MarshalJSON()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, json.RawMessage(data))
+}
+
+// ValidateProject reports problems in a project's compose files that
+// would stop it from starting. Problems found are not an error: the
+// response lists them and says whether the project is valid.
+func (h *Handler) ValidateProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	validation, err := h.manager.ValidateProject(r.Context(), id)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, validation)
+}
+
+// GetProjectGraph returns the dependency graph of a project's services.
+func (h *Handler) GetProjectGraph(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	graph, err := h.manager.ProjectGraph(id)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, graph)
+}
+
 func (h *Handler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
@@ -181,6 +541,111 @@ func (h *Handler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, stats)
 }
 
+func (h *Handler) GetStatsHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	query := r.URL.Query()
+
+	to := time.Now()
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := parseTime(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid to")
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-time.Hour)
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := parseTime(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		respondError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	var step time.Duration
+	if raw := query.Get("step"); raw != "" {
+		parsed, err := parseDuration(raw)
+		if err != nil || parsed <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid step")
+			return
+		}
+		step = parsed
+	}
+
+	history, err := h.manager.GetStatsHistory(id, from, to, step)
+	if err != nil {
+		switch {
+		case errors.Is(err, project.ErrHistoryUnavailable):
+			respondError(w, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, project.ErrProjectNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error("failed to query stats history",
+				"id", id,
+				"error", err,
+			)
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
+
+func (h *Handler) GetProjectLogs(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	query := r.URL.Query()
+
+	opts := project.ProjectLogOptions{
+		Since: query.Get("since"),
+		Until: query.Get("until"),
+		Tail:  100,
+	}
+
+	if tail := query.Get("tail"); tail != "" {
+		if tail == "all" {
+			opts.Tail = 0
+		} else {
+			n, err := strconv.Atoi(tail)
+			if err != nil || n < 0 {
+				respondError(w, http.StatusBadRequest, "invalid tail")
+				return
+			}
+			opts.Tail = n
+		}
+	}
+
+	for _, service := range query["service"] {
+		for _, name := range strings.Split(service, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				opts.Services = append(opts.Services, name)
+			}
+		}
+	}
+
+	if _, err := h.manager.GetProject(id); err != nil {
+		respondError(w, http.StatusNotFound, "project not found")
+		return
+	}
+
+	lines, err := h.manager.GetProjectLogs(r.Context(), id, opts)
+	if err != nil {
+		h.logger.Error("failed to get project logs", "id", id, "error", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, lines)
+}
+
 func (h *Handler) GetContainerLogs(w http.ResponseWriter, r *http.Request) {
 	containerID := chi.URLParam(r, "id")
 	tail := r.URL.Query().Get("tail")
@@ -205,8 +670,12 @@ func (h *Handler) GetContainerLogs(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetSystemInfo(w http.ResponseWriter, r *http.Request) {
-	info, err := h.manager.GetSystemInfo(r.Context())
+	info, err := h.manager.GetSystemInfo(r.Context(), r.URL.Query().Get("host"))
 	if err != nil {
+		if errors.Is(err, project.ErrUnknownHost) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
 		h.logger.Error("failed to get system info", "error", err)
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -216,8 +685,12 @@ func (h *Handler) GetSystemInfo(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetStorageInfo(w http.ResponseWriter, r *http.Request) {
-	info, err := h.manager.GetStorageInfo(r.Context())
+	info, err := h.manager.GetStorageInfo(r.Context(), r.URL.Query().Get("host"))
 	if err != nil {
+		if errors.Is(err, project.ErrUnknownHost) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
 		h.logger.Error("failed to get storage info", "error", err)
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -238,22 +711,61 @@ func (h *Handler) Prune(w http.ResponseWriter, r *http.Request) {
 		req.BuildCache = true
 	}
 
-	reclaimed, err := h.manager.Prune(
-		r.Context(),
-		req.Images,
-		req.Volumes,
-		req.BuildCache,
-	)
+	hostName := r.URL.Query().Get("host")
+	if hostName == "" {
+		hostName = h.manager.DefaultHost()
+	}
+	params := map[string]any{
+		"images":      req.Images,
+		"volumes":     req.Volumes,
+		"build_cache": req.BuildCache,
+	}
+
+	// The entry gets its own params: the job's are read while it runs and
+	// the entry's are added to when it finishes.
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.ActionPrune,
+		TargetType: audit.TargetHost,
+		Target:     hostName,
+		Params:     maps.Clone(params),
+	})
+
+	job, err := h.jobs.Submit(jobs.Spec{
+		Kind:   string(audit.ActionPrune),
+		Key:    "host:" + hostName,
+		Params: params,
+		Actor:  entry.Actor,
+		Run: func(ctx context.Context, output *jobs.Output) error {
+			entry.Time = time.Now()
+			reclaimed, err := h.manager.Prune(
+				ctx,
+				hostName,
+				req.Images,
+				req.Volumes,
+				req.BuildCache,
+			)
+			if err == nil {
+				fmt.Fprintf(output, "reclaimed %d bytes (%.2f MB)\n",
+					reclaimed, float64(reclaimed)/1024/1024)
+				entry.Params["reclaimed_bytes"] = reclaimed
+			}
+			recordAudit(h.audit, h.logger, entry, nil, err)
+			return err
+		},
+		OnCancel: func() {
+			recordAudit(h.audit, h.logger, entry, nil, jobs.ErrJobCancelled)
+		},
+	})
 	if err != nil {
-		h.logger.Error("failed to prune", "error", err)
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]any{
-		"reclaimed_bytes": reclaimed,
-		"reclaimed_mb":    float64(reclaimed) / 1024 / 1024,
-	})
+	respondJob(w, job)
+}
+
+func (h *Handler) ListHosts(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.manager.ListHosts(r.Context()))
 }
 
 func (h *Handler) CheckPort(w http.ResponseWriter, r *http.Request) {
@@ -273,13 +785,29 @@ func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
-	if _, err := h.manager.GetSystemInfo(r.Context()); err != nil {
+	if _, err := h.manager.GetSystemInfo(r.Context(), ""); err != nil {
 		respondError(w, http.StatusServiceUnavailable, "docker not available")
 		return
 	}
 	respondJSON(w, http.StatusOK, map[string]string{"status": "ready"})
 }
 
+// parseTime accepts RFC3339 timestamps or unix seconds.
+func parseTime(raw string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// parseDuration accepts Go durations ("30s", "5m") or plain seconds.
+func parseDuration(raw string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(raw)
+}
+
 func respondJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -289,3 +817,57 @@ func respondJSON(w http.ResponseWriter, status int, data any) {
 func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
+
+// composeProject looks up a project for a compose operation, responding
+// with an error if it is unknown or its compose file is unavailable.
+func (h *Handler) composeProject(
+	w http.ResponseWriter,
+	id string,
+) (*model.Project, bool) {
+	proj, err := h.manager.GetProject(id)
+	if err != nil {
+		respondProjectError(w, err)
+		return nil, false
+	}
+
+	if proj.ReadOnly {
+		respondErrorCode(w, http.StatusConflict, codeProjectReadOnly,
+			"project is read-only - its compose file is not available")
+		return nil, false
+	}
+
+	return proj, true
+}
+
+// respondProjectError maps manager errors for project operations to
+// status codes.
+func respondProjectError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, project.ErrProjectProtected):
+		respondErrorCode(w, http.StatusForbidden, codeProjectProtected, err.Error())
+	case errors.Is(err, project.ErrProjectNotFound),
+		errors.Is(err, project.ErrServiceNotFound),
+		errors.Is(err, project.ErrUnknownHost):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, project.ErrReadOnly):
+		respondErrorCode(w, http.StatusConflict, codeProjectReadOnly, err.Error())
+	case errors.Is(err, project.ErrUnsupported),
+		errors.Is(err, project.ErrInvalidStack),
+		errors.Is(err, project.ErrInvalidProfile),
+		errors.Is(err, project.ErrInvalidEnvFile):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// Reason codes let clients tell apart the causes of a 403.
+const (
+	codeInsufficientRole = "insufficient_role"
+	codeProjectProtected = "project_protected"
+	codeProjectReadOnly  = "project_read_only"
+)
+
+func respondErrorCode(w http.ResponseWriter, status int, code, message string) {
+	respondJSON(w, status, map[string]string{"error": message, "code": code})
+}
@@ -0,0 +1,218 @@
+/*
+AngelaMos | 2026
+jobs.go
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/jobs"
+)
+
+// composeFunc runs a compose operation for a job, reporting output lines
+// to onLine as they arrive.
+type composeFunc func(
+	ctx context.Context,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error)
+
+// submitProjectJob queues a compose operation on a project. Jobs on the
+// same project run one at a time. Clients that accept text/event-stream
+// get the job's output live; others get 202 with the job.
+func (h *Handler) submitProjectJob(
+	w http.ResponseWriter,
+	r *http.Request,
+	action audit.Action,
+	id string,
+	params map[string]any,
+	run composeFunc,
+) {
+	entry := h.auditProject(r, action, id, params)
+
+	job, err := h.jobs.Submit(jobs.Spec{
+		Kind:      string(action),
+		ProjectID: id,
+		Key:       "project:" + id,
+		Params:    params,
+		Actor:     entry.Actor,
+		Run: func(ctx context.Context, output *jobs.Output) error {
+			entry.Time = time.Now()
+			result, err := run(ctx, func(line docker.LogLine) {
+				output.Line(jobs.Line{
+					Stream:    line.Stream,
+					Timestamp: line.Timestamp,
+					Text:      line.Text,
+				})
+			})
+			recordAudit(h.audit, h.logger, entry, result, err)
+			return err
+		},
+		OnCancel: func() {
+			recordAudit(h.audit, h.logger, entry, nil, jobs.ErrJobCancelled)
+		},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if acceptsEventStream(r) {
+		h.streamJob(w, r, job)
+		return
+	}
+
+	respondJob(w, job)
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// streamJob sends a job's progress as server-sent events: a "job" event
+// with the state so far, including any output already produced, an
+// "output" event per further line and a final "done" event with the
+// finished job. If the client falls behind, a "dropped" event with the
+// number of lines skipped follows the lines sent before them; the "done"
+// event still carries the full output. Disconnecting does not cancel the
+// job.
+func (h *Handler) streamJob(w http.ResponseWriter, r *http.Request, job *jobs.Job) {
+	sub, err := h.jobs.Subscribe(job.ID)
+	if err != nil {
+		respondJob(w, job)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	send := func(event string, data any) bool {
+		if err := writeEvent(w, event, data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !send("job", sub.Job) {
+		return
+	}
+
+	// Lines are only dropped while the buffer is full, so they came after
+	// every buffered line; report them once the buffer is drained.
+	sendDropped := func() bool {
+		if len(sub.Lines) > 0 {
+			return true
+		}
+		if n := sub.Dropped(); n > 0 {
+			return send("dropped", map[string]int{"lines": n})
+		}
+		return true
+	}
+
+	for {
+		select {
+		case line := <-sub.Lines:
+			if !send("output", line) || !sendDropped() {
+				return
+			}
+
+		case <-sub.Done:
+			// Lines are published before the job finishes; drain them.
+			for len(sub.Lines) > 0 {
+				if !send("output", <-sub.Lines) || !sendDropped() {
+					return
+				}
+			}
+			if !sendDropped() {
+				return
+			}
+
+			final, err := h.jobs.Get(job.ID)
+			if err != nil {
+				return
+			}
+			send("done", final)
+			return
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+func respondJob(w http.ResponseWriter, job *jobs.Job) {
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	respondJSON(w, http.StatusAccepted, job)
+}
+
+func (h *Handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.jobs.List(r.URL.Query().Get("project")))
+}
+
+func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, job)
+}
+
+func (h *Handler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.ActionCancelJob,
+		TargetType: audit.TargetJob,
+		Target:     id,
+	})
+
+	job, err := h.jobs.Cancel(id)
+	if job != nil {
+		entry.TargetName = job.Kind
+		if job.ProjectID != "" {
+			entry.Params = map[string]any{"project_id": job.ProjectID}
+		}
+	}
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, jobs.ErrJobNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, jobs.ErrJobFinished):
+			status = http.StatusConflict
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, job)
+}
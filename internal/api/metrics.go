@@ -0,0 +1,251 @@
+/*
+AngelaMos | 2026
+metrics.go
+*/
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/metrics"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/project"
+)
+
+const (
+	// statsMaxAge lets scrapes reuse samples from the stats streamer or
+	// history recorder instead of querying docker for every container.
+	statsMaxAge = 15 * time.Second
+	// storageCacheTTL bounds how often disk usage is computed; it walks
+	// every image and volume and is slow on busy hosts.
+	storageCacheTTL = time.Minute
+)
+
+var projectStatuses = []model.ProjectStatus{
+	model.StatusRunning,
+	model.StatusPartial,
+	model.StatusStopped,
+	model.StatusUnknown,
+}
+
+type MetricsHandler struct {
+	manager *project.Manager
+	http    *metrics.HTTPMetrics
+
+	storageMu      sync.Mutex
+	storage        map[string]*model.StorageInfo
+	storageFetched time.Time
+}
+
+// NewMetricsHandler creates a Prometheus scrape handler.
+func NewMetricsHandler(
+	manager *project.Manager,
+	httpMetrics *metrics.HTTPMetrics,
+) *MetricsHandler {
+	return &MetricsHandler{
+		manager: manager,
+		http:    httpMetrics,
+	}
+}
+
+// ServeHTTP writes project, container, storage and HTTP metrics in the
+// Prometheus text exposition format.
+func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projects := h.manager.ListProjects()
+	stats := h.manager.CurrentStats(ctx, statsMaxAge)
+
+	families := h.projectFamilies(projects)
+	families = append(families, containerFamilies(projects, stats)...)
+	families = append(families, h.storageFamilies(ctx)...)
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	for _, family := range families {
+		if _, err := family.WriteTo(w); err != nil {
+			return
+		}
+	}
+	_, _ = h.http.WriteTo(w)
+}
+
+func (h *MetricsHandler) projectFamilies(
+	projects []*model.Project,
+) []io.WriterTo {
+	status := metrics.NewGauge(
+		"holophyly_project_status",
+		"Current project status; 1 for the active status, 0 otherwise.",
+	)
+	protected := metrics.NewGauge(
+		"holophyly_project_protected",
+		"Whether the project is protected from stop and restart.",
+	)
+	containers := metrics.NewGauge(
+		"holophyly_project_containers",
+		"Containers belonging to the project, by state.",
+	)
+
+	for _, proj := range projects {
+		labels := []string{
+			"project", proj.Name,
+			"project_id", proj.ID,
+			"host", proj.Host,
+			"environment", string(proj.Environment),
+		}
+
+		for _, s := range projectStatuses {
+			value := 0.0
+			if proj.Status == s {
+				value = 1
+			}
+			status.Add(value, append(labels, "status", string(s))...)
+		}
+
+		protected.Add(boolValue(proj.Protected), labels...)
+
+		byState := make(map[string]int)
+		for _, ctr := range proj.Containers {
+			byState[ctr.State]++
+		}
+		for state, count := range byState {
+			containers.Add(float64(count), append(labels, "state", state)...)
+		}
+	}
+
+	return []io.WriterTo{status, protected, containers}
+}
+
+func containerFamilies(
+	projects []*model.Project,
+	stats map[string]*model.ContainerStats,
+) []io.WriterTo {
+	cpu := metrics.NewGauge(
+		"holophyly_container_cpu_percent",
+		"Container CPU usage as a percentage of one core.",
+	)
+	memUsage := metrics.NewGauge(
+		"holophyly_container_memory_usage_bytes",
+		"Container memory usage.",
+	)
+	memLimit := metrics.NewGauge(
+		"holophyly_container_memory_limit_bytes",
+		"Container memory limit.",
+	)
+	netRx := metrics.NewCounter(
+		"holophyly_container_network_receive_bytes_total",
+		"Bytes received across all container networks.",
+	)
+	netTx := metrics.NewCounter(
+		"holophyly_container_network_transmit_bytes_total",
+		"Bytes transmitted across all container networks.",
+	)
+	blkRead := metrics.NewCounter(
+		"holophyly_container_block_read_bytes_total",
+		"Bytes read from block devices.",
+	)
+	blkWrite := metrics.NewCounter(
+		"holophyly_container_block_write_bytes_total",
+		"Bytes written to block devices.",
+	)
+	pids := metrics.NewGauge(
+		"holophyly_container_pids",
+		"Processes running in the container.",
+	)
+
+	for _, proj := range projects {
+		for _, ctr := range proj.Containers {
+			s, ok := stats[ctr.ID]
+			if !ok {
+				continue
+			}
+
+			labels := []string{
+				"project", proj.Name,
+				"service", ctr.ServiceName,
+				"container", ctr.Name,
+				"environment", string(proj.Environment),
+				"host", ctr.Host,
+			}
+
+			cpu.Add(s.CPUPercent, labels...)
+			memUsage.Add(float64(s.MemoryUsage), labels...)
+			memLimit.Add(float64(s.MemoryLimit), labels...)
+			netRx.Add(float64(s.NetworkRx), labels...)
+			netTx.Add(float64(s.NetworkTx), labels...)
+			blkRead.Add(float64(s.BlockRead), labels...)
+			blkWrite.Add(float64(s.BlockWrite), labels...)
+			pids.Add(float64(s.PIDs), labels...)
+		}
+	}
+
+	return []io.WriterTo{
+		cpu, memUsage, memLimit, netRx, netTx, blkRead, blkWrite, pids,
+	}
+}
+
+func (h *MetricsHandler) storageFamilies(ctx context.Context) []io.WriterTo {
+	storage := metrics.NewGauge(
+		"holophyly_docker_storage_bytes",
+		"Docker disk usage by resource type.",
+	)
+	reclaimable := metrics.NewGauge(
+		"holophyly_docker_storage_reclaimable_bytes",
+		"Docker disk space reclaimable by pruning.",
+	)
+
+	for host, info := range h.storageInfo(ctx) {
+		storage.Add(float64(info.ImagesSize), "host", host, "type", "images")
+		storage.Add(
+			float64(info.ContainersSize),
+			"host", host, "type", "containers",
+		)
+		storage.Add(float64(info.VolumesSize), "host", host, "type", "volumes")
+		storage.Add(
+			float64(info.BuildCacheSize),
+			"host", host, "type", "build_cache",
+		)
+		reclaimable.Add(float64(info.Reclaimable), "host", host)
+	}
+
+	return []io.WriterTo{storage, reclaimable}
+}
+
+func (h *MetricsHandler) storageInfo(
+	ctx context.Context,
+) map[string]*model.StorageInfo {
+	h.storageMu.Lock()
+	defer h.storageMu.Unlock()
+
+	if h.storage != nil && time.Since(h.storageFetched) < storageCacheTTL {
+		return h.storage
+	}
+
+	storage := make(map[string]*model.StorageInfo)
+	for _, host := range h.manager.ListHosts(ctx) {
+		if !host.Available {
+			continue
+		}
+		info, err := h.manager.GetStorageInfo(ctx, host.Name)
+		if err != nil {
+			continue
+		}
+		storage[host.Name] = info
+	}
+
+	h.storage = storage
+	h.storageFetched = time.Now()
+	return storage
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -6,11 +6,17 @@ middleware.go
 package api
 
 import (
+	"bufio"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/carterperez-dev/holophyly/internal/metrics"
 )
 
 type responseWriter struct {
@@ -30,8 +36,33 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Flush lets streaming responses such as server-sent events through.
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack is required for WebSocket upgrades.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	rw.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // NewLoggingMiddleware creates a structured logging middleware using slog.
-func NewLoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+// Completed requests are also recorded in httpMetrics when it is non-nil.
+func NewLoggingMiddleware(
+	logger *slog.Logger,
+	httpMetrics *metrics.HTTPMetrics,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -43,6 +74,14 @@ func NewLoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 
 			duration := time.Since(start)
 
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+			httpMetrics.Observe(r.Method, route, wrapped.status, duration)
+
 			level := slog.LevelInfo
 			if wrapped.status >= 500 {
 				level = slog.LevelError
@@ -14,6 +14,10 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/auth"
+	"github.com/carterperez-dev/holophyly/internal/jobs"
+	"github.com/carterperez-dev/holophyly/internal/metrics"
 	"github.com/carterperez-dev/holophyly/internal/project"
 	"github.com/carterperez-dev/holophyly/internal/websocket"
 )
@@ -21,6 +25,9 @@ import (
 type RouterConfig struct {
 	Manager        *project.Manager
 	Hub            *websocket.Hub
+	Jobs           *jobs.Runner
+	Auth           *auth.Service
+	Audit          *audit.Log
 	Logger         *slog.Logger
 	AllowedOrigins []string
 }
@@ -28,10 +35,11 @@ type RouterConfig struct {
 // NewRouter creates a Chi router with all API routes configured.
 func NewRouter(cfg RouterConfig) *chi.Mux {
 	r := chi.NewRouter()
+	httpMetrics := metrics.NewHTTPMetrics()
 
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(NewLoggingMiddleware(cfg.Logger))
+	r.Use(NewLoggingMiddleware(cfg.Logger, httpMetrics))
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 
@@ -54,39 +62,117 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		MaxAge:           300,
 	}))
 
-	handler := NewHandler(cfg.Manager, cfg.Logger)
+	handler := NewHandler(cfg.Manager, cfg.Jobs, cfg.Audit, cfg.Logger)
+
+	// Without an auth service every request acts as the anonymous admin.
+	requireAuth := AnonymousAccess
+	if cfg.Auth != nil {
+		requireAuth = RequireAuth(cfg.Auth)
+	}
+
+	canRead := RequirePermission(auth.PermRead)
+	canOperate := RequirePermission(auth.PermOperate)
 
 	r.Get("/health", handler.Health)
 	r.Get("/ready", handler.Ready)
+	r.With(requireAuth, canRead).
+		Get("/metrics", NewMetricsHandler(cfg.Manager, httpMetrics).ServeHTTP)
 
 	r.Route("/api", func(r chi.Router) {
-		r.Route("/projects", func(r chi.Router) {
-			r.Get("/", handler.ListProjects)
-			r.Get("/{id}", handler.GetProject)
-			r.Post("/{id}/start", handler.StartProject)
-			r.Post("/{id}/stop", handler.StopProject)
-			r.Post("/{id}/restart", handler.RestartProject)
-			r.Post("/{id}/protect", handler.SetProjectProtection)
-			r.Put("/{id}/name", handler.SetProjectDisplayName)
-			r.Put("/{id}/hidden", handler.SetProjectHidden)
-			r.Get("/{id}/stats", handler.GetProjectStats)
-		})
-
-		r.Route("/containers", func(r chi.Router) {
-			r.Get("/{id}/logs", handler.GetContainerLogs)
-		})
+		if cfg.Auth != nil {
+			authHandler := NewAuthHandler(cfg.Auth, cfg.Audit, cfg.Logger)
+
+			r.Post("/auth/login", authHandler.Login)
+
+			r.Group(func(r chi.Router) {
+				r.Use(requireAuth)
+				r.Post("/auth/logout", authHandler.Logout)
+				r.Get("/auth/me", authHandler.Me)
+
+				r.Route("/tokens", func(r chi.Router) {
+					r.Use(RequirePermission(auth.PermManageTokens))
+					r.Get("/", authHandler.ListTokens)
+					r.Post("/", authHandler.CreateToken)
+					r.Delete("/{id}", authHandler.RevokeToken)
+				})
+			})
+		}
 
-		r.Route("/system", func(r chi.Router) {
-			r.Get("/info", handler.GetSystemInfo)
-			r.Get("/storage", handler.GetStorageInfo)
-			r.Post("/prune", handler.Prune)
-			r.Get("/port/{port}", handler.CheckPort)
+		r.Group(func(r chi.Router) {
+			r.Use(requireAuth, canRead)
+
+			r.Route("/projects", func(r chi.Router) {
+				r.Get("/", handler.ListProjects)
+				r.Get("/{id}", handler.GetProject)
+				r.With(canOperate).Post("/{id}/start", handler.StartProject)
+				r.With(canOperate).Post("/{id}/stop", handler.StopProject)
+				r.With(canOperate).Post("/{id}/restart", handler.RestartProject)
+				r.With(canOperate).Post("/{id}/pull", handler.PullProject)
+				r.With(canOperate).Post(
+					"/{id}/services/{service}/{action}",
+					handler.ServiceAction,
+				)
+				r.With(RequirePermission(auth.PermProtect)).
+					Post("/{id}/protect", handler.SetProjectProtection)
+				r.With(RequirePermission(auth.PermProtect)).
+					Delete("/{id}/protect", handler.ClearProjectProtection)
+				r.With(canOperate).Put("/{id}/name", handler.SetProjectDisplayName)
+				r.With(canOperate).Put("/{id}/hidden", handler.SetProjectHidden)
+				r.With(canOperate).Put("/{id}/compose-files", handler.SetComposeFiles)
+				r.With(canOperate).Put("/{id}/profiles", handler.SetProfiles)
+				r.Get("/{id}/env", handler.GetProjectEnv)
+				r.With(canOperate).Put("/{id}/env-files", handler.SetEnvFiles)
+				r.Get("/{id}/config", handler.GetProjectConfig)
+				r.Post("/{id}/validate", handler.ValidateProject)
+				r.Get("/{id}/graph", handler.GetProjectGraph)
+				r.Get("/{id}/stats", handler.GetProjectStats)
+				r.Get("/{id}/stats/history", handler.GetStatsHistory)
+				r.Get("/{id}/logs", handler.GetProjectLogs)
+			})
+
+			r.Route("/containers", func(r chi.Router) {
+				r.Get("/", handler.ListContainers)
+				r.Get("/{id}", handler.InspectContainer)
+				r.Get("/{id}/logs", handler.GetContainerLogs)
+				r.With(canOperate).Post("/{id}/{action}", handler.ContainerAction)
+			})
+
+			r.Route("/jobs", func(r chi.Router) {
+				r.Get("/", handler.ListJobs)
+				r.Get("/{id}", handler.GetJob)
+				r.With(canOperate).Post("/{id}/cancel", handler.CancelJob)
+			})
+
+			r.Route("/scanner", func(r chi.Router) {
+				r.Get("/status", handler.ScannerStatus)
+				r.With(canOperate).Post("/rescan", handler.Rescan)
+				r.With(RequirePermission(auth.PermScanPaths)).
+					Put("/paths", handler.SetScanPaths)
+			})
+
+			r.Get("/hosts", handler.ListHosts)
+
+			r.With(RequirePermission(auth.PermViewAudit)).
+				Get("/audit", handler.ListAudit)
+
+			r.Route("/system", func(r chi.Router) {
+				r.Get("/info", handler.GetSystemInfo)
+				r.Get("/storage", handler.GetStorageInfo)
+				r.With(RequirePermission(auth.PermPrune)).
+					Post("/prune", handler.Prune)
+				r.Get("/port/{port}", handler.CheckPort)
+			})
 		})
 	})
 
 	if cfg.Hub != nil {
-		wsHandler := websocket.NewHTTPHandler(cfg.Hub, cfg.Manager, cfg.Logger)
-		r.Get("/ws/stats", wsHandler.HandleWebSocket)
+		wsHandler := websocket.NewHTTPHandler(
+			cfg.Hub,
+			cfg.Manager,
+			origins,
+			cfg.Logger,
+		)
+		r.With(requireAuth, canRead).Get("/ws/stats", wsHandler.HandleWebSocket)
 	}
 
 	return r
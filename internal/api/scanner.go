@@ -0,0 +1,67 @@
+/*
+AngelaMos | 2026
+scanner.go
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/carterperez-dev/holophyly/internal/audit"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+func (h *Handler) ScannerStatus(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.manager.ScanStatus())
+}
+
+// Rescan reloads every compose file rather than only changed ones, and
+// responds with the resulting scan status.
+func (h *Handler) Rescan(w http.ResponseWriter, r *http.Request) {
+	if err := h.manager.Rescan(r.Context()); err != nil {
+		h.logger.Error("failed to rescan projects", "error", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.manager.ScanStatus())
+}
+
+// SetScanPaths replaces the directories scanned for compose files until
+// the server restarts, then rescans them.
+func (h *Handler) SetScanPaths(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Paths []string `json:"paths"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	entry := auditEntry(r, &audit.Entry{
+		Action:     audit.ActionScanPaths,
+		TargetType: audit.TargetScanner,
+		Params: map[string]any{
+			"paths":    req.Paths,
+			"previous": h.manager.ScanStatus().Paths,
+		},
+	})
+	err := h.manager.SetScanPaths(r.Context(), req.Paths)
+	recordAudit(h.audit, h.logger, entry, nil, err)
+
+	if err != nil {
+		if errors.Is(err, scanner.ErrInvalidPath) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to rescan new scan paths", "error", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.manager.ScanStatus())
+}
@@ -0,0 +1,184 @@
+/*
+AngelaMos | 2026
+audit.go
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+type Action string
+
+const (
+	ActionStart       Action = "start"
+	ActionStop        Action = "stop"
+	ActionRestart     Action = "restart"
+	ActionPull        Action = "pull"
+	ActionRecreate    Action = "recreate"
+	ActionBuild       Action = "build"
+	ActionKill        Action = "kill"
+	ActionPause       Action = "pause"
+	ActionUnpause     Action = "unpause"
+	ActionRemove      Action = "remove"
+	ActionProtect     Action = "protect"
+	ActionPrune       Action = "prune"
+	ActionRename      Action = "rename"
+	ActionHide        Action = "hide"
+	ActionSelectFiles Action = "select_files"
+	ActionSetProfiles Action = "set_profiles"
+	ActionSetEnvFiles Action = "set_env_files"
+	ActionTokenCreate Action = "token_create"
+	ActionTokenRevoke Action = "token_revoke"
+	ActionScanPaths   Action = "scan_paths"
+	ActionCancelJob   Action = "cancel_job"
+)
+
+type Result string
+
+const (
+	ResultSuccess Result = "success"
+	ResultFailure Result = "failure"
+)
+
+const (
+	TargetProject   = "project"
+	TargetContainer = "container"
+	TargetHost      = "host"
+	TargetToken     = "token"
+	TargetScanner   = "scanner"
+	TargetJob       = "job"
+)
+
+type Entry struct {
+	ID         int64          `json:"id"`
+	Time       time.Time      `json:"time"`
+	ActorID    string         `json:"actor_id,omitempty"`
+	Actor      string         `json:"actor"`
+	Action     Action         `json:"action"`
+	TargetType string         `json:"target_type,omitempty"`
+	Target     string         `json:"target,omitempty"`
+	TargetName string         `json:"target_name,omitempty"`
+	Params     map[string]any `json:"params,omitempty"`
+	Result     Result         `json:"result"`
+	Output     string         `json:"output,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	DurationMS int64          `json:"duration_ms"`
+	RequestID  string         `json:"request_id,omitempty"`
+}
+
+type Page struct {
+	Entries []*Entry `json:"entries"`
+	Total   int      `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+}
+
+type Log struct {
+	store    *store.Store
+	onRecord func(*Entry)
+	mu       sync.RWMutex
+}
+
+// New creates an audit log persisted in the SQLite store.
+func New(prefStore *store.Store) *Log {
+	return &Log{store: prefStore}
+}
+
+// OnRecord registers a callback invoked after each entry is stored.
+func (l *Log) OnRecord(fn func(*Entry)) {
+	l.mu.Lock()
+	l.onRecord = fn
+	l.mu.Unlock()
+}
+
+// Record stores an entry, assigning its ID.
+func (l *Log) Record(entry *Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	var params string
+	if len(entry.Params) > 0 {
+		data, err := json.Marshal(entry.Params)
+		if err != nil {
+			return fmt.Errorf("encoding audit params: %w", err)
+		}
+		params = string(data)
+	}
+
+	record := &store.AuditRecord{
+		Time:       entry.Time,
+		ActorID:    entry.ActorID,
+		Actor:      entry.Actor,
+		Action:     string(entry.Action),
+		TargetType: entry.TargetType,
+		Target:     entry.Target,
+		TargetName: entry.TargetName,
+		Params:     params,
+		Result:     string(entry.Result),
+		Output:     entry.Output,
+		Error:      entry.Error,
+		DurationMS: entry.DurationMS,
+		RequestID:  entry.RequestID,
+	}
+
+	if err := l.store.InsertAudit(record); err != nil {
+		return fmt.Errorf("storing audit entry: %w", err)
+	}
+	entry.ID = record.ID
+
+	l.mu.RLock()
+	fn := l.onRecord
+	l.mu.RUnlock()
+
+	if fn != nil {
+		fn(entry)
+	}
+
+	return nil
+}
+
+// Query returns a page of entries, newest first.
+func (l *Log) Query(filter store.AuditFilter) (*Page, error) {
+	records, total, err := l.store.QueryAudit(filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying audit log: %w", err)
+	}
+
+	entries := make([]*Entry, 0, len(records))
+	for _, record := range records {
+		entry := &Entry{
+			ID:         record.ID,
+			Time:       record.Time,
+			ActorID:    record.ActorID,
+			Actor:      record.Actor,
+			Action:     Action(record.Action),
+			TargetType: record.TargetType,
+			Target:     record.Target,
+			TargetName: record.TargetName,
+			Result:     Result(record.Result),
+			Output:     record.Output,
+			Error:      record.Error,
+			DurationMS: record.DurationMS,
+			RequestID:  record.RequestID,
+		}
+		if record.Params != "" {
+			_ = json.Unmarshal([]byte(record.Params), &entry.Params)
+		}
+		entries = append(entries, entry)
+	}
+
+	return &Page{
+		Entries: entries,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}, nil
+}
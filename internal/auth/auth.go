@@ -0,0 +1,321 @@
+/*
+AngelaMos | 2026
+auth.go
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+const (
+	tokenPrefix = "hlp_"
+
+	// touchInterval throttles last-used writes so every request does not
+	// hit the database.
+	touchInterval = time.Minute
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid or expired credentials")
+	ErrTokenNotFound      = errors.New("token not found")
+)
+
+type Principal struct {
+	TokenID   string `json:"token_id"`
+	TokenName string `json:"token_name"`
+	Role      Role   `json:"role"`
+	Method    string `json:"method"`
+}
+
+type Token struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       Role       `json:"role"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type Service struct {
+	store      *store.Store
+	sessionTTL time.Duration
+	touched    map[string]time.Time
+	mu         sync.Mutex
+}
+
+// NewService creates an auth service backed by the SQLite store.
+func NewService(prefStore *store.Store, sessionTTL time.Duration) *Service {
+	if sessionTTL <= 0 {
+		sessionTTL = 24 * time.Hour
+	}
+
+	return &Service{
+		store:      prefStore,
+		sessionTTL: sessionTTL,
+		touched:    make(map[string]time.Time),
+	}
+}
+
+// SessionTTL returns how long login sessions stay valid.
+func (s *Service) SessionTTL() time.Duration {
+	return s.sessionTTL
+}
+
+// Bootstrap creates an initial admin token when no active tokens exist, so
+// a fresh install can be accessed. Returns the plaintext token, or "" if
+// tokens already exist.
+func (s *Service) Bootstrap() (string, error) {
+	count, err := s.store.CountActiveTokens()
+	if err != nil {
+		return "", fmt.Errorf("counting tokens: %w", err)
+	}
+	if count > 0 {
+		return "", nil
+	}
+
+	plaintext, _, err := s.CreateToken("bootstrap", RoleAdmin, 0)
+	return plaintext, err
+}
+
+// CreateToken issues a new API token. A zero ttl never expires.
+// The plaintext is returned once; only its hash is stored.
+func (s *Service) CreateToken(
+	name string,
+	role Role,
+	ttl time.Duration,
+) (string, *Token, error) {
+	if _, err := ParseRole(string(role)); err != nil {
+		return "", nil, err
+	}
+
+	secret, err := randomString(32)
+	if err != nil {
+		return "", nil, err
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plaintext := tokenPrefix + secret
+	now := time.Now()
+
+	record := &store.APIToken{
+		ID:        id,
+		Name:      name,
+		Prefix:    plaintext[:len(tokenPrefix)+6],
+		Hash:      hashSecret(plaintext),
+		Role:      string(role),
+		CreatedAt: now,
+	}
+	if ttl > 0 {
+		expires := now.Add(ttl)
+		record.ExpiresAt = &expires
+	}
+
+	if err := s.store.CreateToken(record); err != nil {
+		return "", nil, fmt.Errorf("storing token: %w", err)
+	}
+
+	return plaintext, toToken(record), nil
+}
+
+func (s *Service) ListTokens() ([]*Token, error) {
+	records, err := s.store.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*Token, 0, len(records))
+	for _, record := range records {
+		tokens = append(tokens, toToken(record))
+	}
+	return tokens, nil
+}
+
+func (s *Service) RevokeToken(id string) error {
+	revoked, err := s.store.RevokeToken(id, time.Now())
+	if err != nil {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("%w: %s", ErrTokenNotFound, id)
+	}
+	return nil
+}
+
+// AuthenticateToken validates a bearer token.
+func (s *Service) AuthenticateToken(plaintext string) (*Principal, error) {
+	if !strings.HasPrefix(plaintext, tokenPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+
+	record, err := s.store.GetTokenByHash(hashSecret(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("looking up token: %w", err)
+	}
+
+	if err := s.checkUsable(record); err != nil {
+		return nil, err
+	}
+
+	s.touch(record.ID)
+
+	return &Principal{
+		TokenID:   record.ID,
+		TokenName: record.Name,
+		Role:      Role(record.Role),
+		Method:    "token",
+	}, nil
+}
+
+// Login exchanges a token for a session ID suitable for a cookie.
+func (s *Service) Login(plaintext string) (string, time.Time, error) {
+	principal, err := s.AuthenticateToken(plaintext)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	sessionID, err := randomString(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	session := &store.Session{
+		TokenID:   principal.TokenID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	}
+
+	if err := s.store.CreateSession(hashSecret(sessionID), session); err != nil {
+		return "", time.Time{}, fmt.Errorf("storing session: %w", err)
+	}
+
+	_ = s.store.DeleteExpiredSessions(now)
+
+	return sessionID, session.ExpiresAt, nil
+}
+
+// AuthenticateSession validates a session cookie. Sessions die with the
+// token that created them.
+func (s *Service) AuthenticateSession(sessionID string) (*Principal, error) {
+	session, err := s.store.GetSession(hashSecret(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("looking up session: %w", err)
+	}
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil, ErrInvalidCredentials
+	}
+
+	record, err := s.store.GetToken(session.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up token: %w", err)
+	}
+
+	if err := s.checkUsable(record); err != nil {
+		return nil, err
+	}
+
+	s.touch(record.ID)
+
+	return &Principal{
+		TokenID:   record.ID,
+		TokenName: record.Name,
+		Role:      Role(record.Role),
+		Method:    "session",
+	}, nil
+}
+
+func (s *Service) Logout(sessionID string) error {
+	return s.store.DeleteSession(hashSecret(sessionID))
+}
+
+func (s *Service) checkUsable(record *store.APIToken) error {
+	if record == nil || record.RevokedAt != nil {
+		return ErrInvalidCredentials
+	}
+	if record.ExpiresAt != nil && time.Now().After(*record.ExpiresAt) {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+func (s *Service) touch(tokenID string) {
+	now := time.Now()
+
+	s.mu.Lock()
+	last := s.touched[tokenID]
+	if now.Sub(last) < touchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[tokenID] = now
+	s.mu.Unlock()
+
+	_ = s.store.TouchToken(tokenID, now)
+}
+
+type contextKey struct{}
+
+// WithPrincipal attaches an authenticated principal to a context.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the authenticated principal, or nil.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(contextKey{}).(*Principal)
+	return p
+}
+
+func toToken(record *store.APIToken) *Token {
+	return &Token{
+		ID:         record.ID,
+		Name:       record.Name,
+		Prefix:     record.Prefix,
+		Role:       Role(record.Role),
+		CreatedAt:  record.CreatedAt,
+		ExpiresAt:  record.ExpiresAt,
+		LastUsedAt: record.LastUsedAt,
+		RevokedAt:  record.RevokedAt,
+	}
+}
+
+// hashSecret hashes high-entropy secrets for storage. A fast hash is
+// sufficient because the inputs are random, not user-chosen passwords.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating random bytes: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
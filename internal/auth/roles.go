@@ -0,0 +1,89 @@
+/*
+AngelaMos | 2026
+roles.go
+*/
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+// Permission names an action guarded by a minimum role.
+type Permission string
+
+const (
+	PermRead         Permission = "read"
+	PermOperate      Permission = "operate"
+	PermForceStop    Permission = "force_stop"
+	PermProtect      Permission = "protect"
+	PermPrune        Permission = "prune"
+	PermManageTokens Permission = "manage_tokens"
+	PermViewAudit    Permission = "view_audit"
+	PermScanPaths    Permission = "scan_paths"
+)
+
+var ErrInvalidRole = errors.New("invalid role")
+
+var roleRank = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+var permissionRole = map[Permission]Role{
+	PermRead:         RoleViewer,
+	PermOperate:      RoleOperator,
+	PermForceStop:    RoleAdmin,
+	PermProtect:      RoleAdmin,
+	PermPrune:        RoleAdmin,
+	PermManageTokens: RoleAdmin,
+	PermViewAudit:    RoleAdmin,
+	PermScanPaths:    RoleAdmin,
+}
+
+// ParseRole validates a role name.
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := roleRank[role]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, s)
+	}
+	return role, nil
+}
+
+// RequiredRole returns the minimum role for a permission.
+// Unknown permissions require admin.
+func RequiredRole(perm Permission) Role {
+	if role, ok := permissionRole[perm]; ok {
+		return role
+	}
+	return RoleAdmin
+}
+
+// Allows reports whether the role grants the permission.
+func (r Role) Allows(perm Permission) bool {
+	return roleRank[r] >= roleRank[RequiredRole(perm)]
+}
+
+// Anonymous is the principal used when authentication is disabled.
+// It has full access, matching behavior before auth existed.
+var Anonymous = &Principal{
+	TokenName: "anonymous",
+	Role:      RoleAdmin,
+	Method:    "none",
+}
+
+// Can reports whether the principal may perform perm. A nil principal
+// is never allowed.
+func (p *Principal) Can(perm Permission) bool {
+	return p != nil && p.Role.Allows(perm)
+}
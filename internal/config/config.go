@@ -16,6 +16,8 @@ import (
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
 )
 
 type Config struct {
@@ -23,6 +25,9 @@ type Config struct {
 	Scanner    ScannerConfig    `koanf:"scanner"`
 	Protection ProtectionConfig `koanf:"protection"`
 	Docker     DockerConfig     `koanf:"docker"`
+	Stats      StatsConfig      `koanf:"stats"`
+	Auth       AuthConfig       `koanf:"auth"`
+	Jobs       JobsConfig       `koanf:"jobs"`
 	Logging    LoggingConfig    `koanf:"logging"`
 	DataDir    string           `koanf:"data_dir"`
 }
@@ -37,6 +42,7 @@ type ScannerConfig struct {
 	Paths        []string      `koanf:"paths"`
 	Exclude      []string      `koanf:"exclude"`
 	ScanInterval time.Duration `koanf:"scan_interval"`
+	Watch        bool          `koanf:"watch"`
 }
 
 type ProtectionConfig struct {
@@ -45,7 +51,38 @@ type ProtectionConfig struct {
 }
 
 type DockerConfig struct {
-	Socket string `koanf:"socket"`
+	Socket string             `koanf:"socket"`
+	Hosts  []DockerHostConfig `koanf:"hosts"`
+}
+
+type DockerHostConfig struct {
+	Name string          `koanf:"name"`
+	Host string          `koanf:"host"`
+	TLS  DockerTLSConfig `koanf:"tls"`
+}
+
+type DockerTLSConfig struct {
+	CACert string `koanf:"ca_cert"`
+	Cert   string `koanf:"cert"`
+	Key    string `koanf:"key"`
+}
+
+type StatsConfig struct {
+	HistoryEnabled  bool          `koanf:"history_enabled"`
+	SampleInterval  time.Duration `koanf:"sample_interval"`
+	RawRetention    time.Duration `koanf:"raw_retention"`
+	MinuteRetention time.Duration `koanf:"minute_retention"`
+	HourRetention   time.Duration `koanf:"hour_retention"`
+}
+
+type AuthConfig struct {
+	Enabled    bool          `koanf:"enabled"`
+	SessionTTL time.Duration `koanf:"session_ttl"`
+}
+
+type JobsConfig struct {
+	Workers   int           `koanf:"workers"`
+	Retention time.Duration `koanf:"retention"`
 }
 
 type LoggingConfig struct {
@@ -120,6 +157,7 @@ func defaultConfig() *Config {
 				"venv",
 			},
 			ScanInterval: 30 * time.Second,
+			Watch:        true,
 		},
 		Protection: ProtectionConfig{
 			Patterns: []string{
@@ -129,7 +167,22 @@ func defaultConfig() *Config {
 			Projects: []string{},
 		},
 		Docker: DockerConfig{
-			Socket: "unix:///var/run/docker.sock",
+			Socket: "",
+		},
+		Stats: StatsConfig{
+			HistoryEnabled:  true,
+			SampleInterval:  10 * time.Second,
+			RawRetention:    6 * time.Hour,
+			MinuteRetention: 7 * 24 * time.Hour,
+			HourRetention:   90 * 24 * time.Hour,
+		},
+		Auth: AuthConfig{
+			Enabled:    true,
+			SessionTTL: 24 * time.Hour,
+		},
+		Jobs: JobsConfig{
+			Workers:   4,
+			Retention: time.Hour,
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
@@ -181,6 +234,28 @@ func (c *Config) expandPaths() {
 			c.Protection.Projects[i] = filepath.Join(home, path[2:])
 		}
 	}
+
+	for i := range c.Docker.Hosts {
+		tls := &c.Docker.Hosts[i].TLS
+		for _, path := range []*string{&tls.CACert, &tls.Cert, &tls.Key} {
+			if strings.HasPrefix(*path, "~/") {
+				*path = filepath.Join(home, (*path)[2:])
+			}
+		}
+	}
+}
+
+// DockerHosts returns the configured Docker endpoints.
+// Without an explicit hosts list, a single endpoint is built from
+// docker.socket (empty means DOCKER_HOST or the platform default).
+func (c *Config) DockerHosts() []DockerHostConfig {
+	if len(c.Docker.Hosts) > 0 {
+		return c.Docker.Hosts
+	}
+
+	return []DockerHostConfig{
+		{Name: docker.DefaultHostName, Host: c.Docker.Socket},
+	}
 }
 
 // Address returns the full server address.
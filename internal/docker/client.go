@@ -8,28 +8,90 @@ package docker
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/docker/docker/client"
 )
 
+// DefaultHostName is the endpoint name used when no hosts are configured.
+const DefaultHostName = "local"
+
+type Endpoint struct {
+	Name      string
+	Host      string
+	TLSCACert string
+	TLSCert   string
+	TLSKey    string
+}
+
 type Client struct {
-	cli *client.Client
-	mu  sync.RWMutex
+	cli      *client.Client
+	endpoint Endpoint
+	mu       sync.RWMutex
 }
 
-// NewClient creates a Docker client with automatic API version negotiation.
-// This handles Docker version differences across different hosts.
-func NewClient() (*Client, error) {
-	cli, err := client.NewClientWithOpts(
+// NewClient creates a Docker client for an endpoint with automatic API
+// version negotiation. An empty host falls back to DOCKER_HOST and the
+// platform default socket. Supports unix://, tcp:// (optionally with TLS)
+// and ssh:// hosts.
+func NewClient(endpoint Endpoint) (*Client, error) {
+	if endpoint.Name == "" {
+		endpoint.Name = DefaultHostName
+	}
+
+	opts := []client.Opt{
 		client.FromEnv,
 		client.WithAPIVersionNegotiation(),
-	)
+	}
+
+	switch {
+	case endpoint.Host == "":
+	case strings.HasPrefix(endpoint.Host, "ssh://"):
+		dialer, err := newSSHDialer(endpoint.Host)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"configuring ssh for %s: %w",
+				endpoint.Name,
+				err,
+			)
+		}
+		opts = append(opts,
+			client.WithHost("http://docker.example.com"),
+			client.WithDialContext(dialer),
+		)
+	default:
+		opts = append(opts, client.WithHost(endpoint.Host))
+	}
+
+	if endpoint.TLSCACert != "" || endpoint.TLSCert != "" {
+		opts = append(opts, client.WithTLSClientConfig(
+			endpoint.TLSCACert,
+			endpoint.TLSCert,
+			endpoint.TLSKey,
+		))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("creating docker client: %w", err)
+		return nil, fmt.Errorf(
+			"creating docker client for %s: %w",
+			endpoint.Name,
+			err,
+		)
 	}
 
-	return &Client{cli: cli}, nil
+	return &Client{cli: cli, endpoint: endpoint}, nil
+}
+
+// Name returns the configured endpoint name of this client.
+func (c *Client) Name() string {
+	return c.endpoint.Name
+}
+
+// Endpoint returns the connection settings of this client.
+func (c *Client) Endpoint() Endpoint {
+	return c.endpoint
 }
 
 // Ping verifies the Docker daemon is reachable and responsive.
@@ -62,3 +124,26 @@ func (c *Client) Raw() *client.Client {
 	defer c.mu.RUnlock()
 	return c.cli
 }
+
+// cliArgs returns the global docker CLI flags that target this endpoint.
+// Used by compose commands, which shell out to the docker binary.
+func (c *Client) cliArgs() []string {
+	if c.endpoint.Host == "" {
+		return nil
+	}
+
+	args := []string{"--host", c.endpoint.Host}
+	if c.endpoint.TLSCACert != "" {
+		args = append(args, "--tlsverify", "--tlscacert", c.endpoint.TLSCACert)
+	} else if c.endpoint.TLSCert != "" {
+		args = append(args, "--tls")
+	}
+	if c.endpoint.TLSCert != "" {
+		args = append(args,
+			"--tlscert", c.endpoint.TLSCert,
+			"--tlskey", c.endpoint.TLSKey,
+		)
+	}
+
+	return args
+}
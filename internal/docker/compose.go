@@ -6,13 +6,16 @@ compose.go
 package docker
 
 import (
+	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,71 +25,145 @@ type ComposeResult struct {
 	Error   string `json:"error,omitempty"`
 }
 
+// ComposeTarget is the project a compose command runs against: its files,
+// passed as repeated -f flags, the profiles to activate and the env files
+// to interpolate with instead of .env. Profiles are passed to every
+// command, so services behind them are stopped and removed along with the
+// rest.
+type ComposeTarget struct {
+	Files    []string
+	Profiles []string
+	EnvFiles []string
+}
+
 /*
-ComposeUp starts services defined in a compose file.
-Equivalent to: docker compose -f <file> up -d
+ComposeUp starts services defined in a project's compose files.
+Equivalent to: docker compose -f <file>... up -d
+Runs against the endpoint this client is connected to.
+Each output line is passed to onLine as it is produced, if non-nil.
 */
-func ComposeUp(
+func (c *Client) ComposeUp(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	onLine LineFunc,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "up", "-d", "--remove-orphans")
+	return c.runComposeCommandStream(
+		ctx,
+		target,
+		onLine,
+		"up", "-d", "--remove-orphans",
+	)
 }
 
 /*
-ComposeDown stops and removes services defined in a compose file.
-Equivalent to: docker compose -f <file> down
+ComposeDown stops and removes services defined in a project's compose
+files. Equivalent to: docker compose -f <file>... down
 */
-func ComposeDown(
+func (c *Client) ComposeDown(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	onLine LineFunc,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "down")
+	return c.runComposeCommandStream(ctx, target, onLine, "down")
 }
 
 /*
-ComposeRestart restarts services defined in a compose file.
+ComposeRestart restarts services defined in a project's compose files.
 */
-func ComposeRestart(
+func (c *Client) ComposeRestart(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	onLine LineFunc,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "restart")
+	return c.runComposeCommandStream(ctx, target, onLine, "restart")
 }
 
 /*
-ComposePull pulls latest images for services defined in a compose file.
+ComposePull pulls latest images for services defined in a project's
+compose files.
 */
-func ComposePull(
+func (c *Client) ComposePull(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	onLine LineFunc,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "pull")
+	return c.runComposeCommandStream(ctx, target, onLine, "pull")
+}
+
+// ServiceAction is a compose operation scoped to a single service.
+type ServiceAction string
+
+const (
+	ServiceStart    ServiceAction = "start"
+	ServiceStop     ServiceAction = "stop"
+	ServiceRestart  ServiceAction = "restart"
+	ServiceRecreate ServiceAction = "recreate"
+	ServicePull     ServiceAction = "pull"
+	ServiceBuild    ServiceAction = "build"
+)
+
+// serviceActionArgs maps each action to its compose arguments; the
+// service name is appended. Recreate skips dependencies so only the
+// named service is replaced.
+var serviceActionArgs = map[ServiceAction][]string{
+	ServiceStart:    {"up", "-d"},
+	ServiceStop:     {"stop"},
+	ServiceRestart:  {"restart"},
+	ServiceRecreate: {"up", "-d", "--force-recreate", "--no-deps"},
+	ServicePull:     {"pull"},
+	ServiceBuild:    {"build"},
+}
+
+// Valid reports whether the action is a known service action.
+func (a ServiceAction) Valid() bool {
+	_, ok := serviceActionArgs[a]
+	return ok
+}
+
+/*
+ComposeService runs a service-scoped compose action, e.g.
+docker compose -f <file>... restart <service>
+*/
+func (c *Client) ComposeService(
+	ctx context.Context,
+	target ComposeTarget,
+	service string,
+	action ServiceAction,
+	onLine LineFunc,
+) (*ComposeResult, error) {
+	base, ok := serviceActionArgs[action]
+	if !ok {
+		return nil, fmt.Errorf("unknown service action: %s", action)
+	}
+
+	args := append(append([]string{}, base...), service)
+	return c.runComposeCommandStream(ctx, target, onLine, args...)
 }
 
 /*
 ComposePs lists containers for a compose project.
 */
-func ComposePs(
+func (c *Client) ComposePs(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "ps", "--format", "json")
+	return c.runComposeCommand(ctx, target, "ps", "--format", "json")
 }
 
 /*
 ComposeLogs gets logs from compose services.
 */
-func ComposeLogs(
+func (c *Client) ComposeLogs(
 	ctx context.Context,
-	composePath, tail string,
+	target ComposeTarget,
+	tail string,
 ) (*ComposeResult, error) {
 	if tail == "" {
 		tail = "100"
 	}
-	return runComposeCommand(
+	return c.runComposeCommand(
 		ctx,
-		composePath,
+		target,
 		"logs",
 		"--tail",
 		tail,
@@ -97,32 +174,107 @@ func ComposeLogs(
 /*
 ComposeConfig validates and returns the compose configuration.
 */
-func ComposeConfig(
+func (c *Client) ComposeConfig(
+	ctx context.Context,
+	target ComposeTarget,
+) (*ComposeResult, error) {
+	return c.runComposeCommand(ctx, target, "config")
+}
+
+func (c *Client) runComposeCommand(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	args ...string,
 ) (*ComposeResult, error) {
-	return runComposeCommand(ctx, composePath, "config")
+	return c.runComposeCommandStream(ctx, target, nil, args...)
 }
 
-func runComposeCommand(
+/*
+runComposeCommandStream runs a compose command, passing each stdout and
+stderr line to onLine as it arrives. Calls to onLine are serialized.
+The full output is still collected into the result.
+*/
+func (c *Client) runComposeCommandStream(
 	ctx context.Context,
-	composePath string,
+	target ComposeTarget,
+	onLine LineFunc,
 	args ...string,
 ) (*ComposeResult, error) {
-	dir := filepath.Dir(composePath)
-	file := filepath.Base(composePath)
+	if len(target.Files) == 0 {
+		return nil, errors.New("no compose files given")
+	}
+
+	// Compose resolves relative paths and the default project name from
+	// the first file's directory, so run from there.
+	dir := filepath.Dir(target.Files[0])
 
-	cmdArgs := []string{"compose", "-f", file}
+	cmdArgs := append(c.cliArgs(), "compose")
+	for _, file := range target.Files {
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			file = rel
+		}
+		cmdArgs = append(cmdArgs, "-f", file)
+	}
+	for _, profile := range target.Profiles {
+		cmdArgs = append(cmdArgs, "--profile", profile)
+	}
+	for _, file := range target.EnvFiles {
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			file = rel
+		}
+		cmdArgs = append(cmdArgs, "--env-file", file)
+	}
 	cmdArgs = append(cmdArgs, args...)
 
 	cmd := exec.CommandContext(ctx, "docker", cmdArgs...)
 	cmd.Dir = dir
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdout pipe: %w", err)
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return &ComposeResult{Error: err.Error()},
+			fmt.Errorf("compose command failed: %w", err)
+	}
+
+	var stdout, stderr strings.Builder
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	collect := func(reader io.Reader, stream string, buf *strings.Builder) {
+		defer wg.Done()
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+		scanner.Split(scanOutputLines)
+
+		for scanner.Scan() {
+			text := scanner.Text()
 
-	err := cmd.Run()
+			mu.Lock()
+			buf.WriteString(text)
+			buf.WriteByte('\n')
+			if onLine != nil && text != "" {
+				onLine(LogLine{Stream: stream, Timestamp: time.Now(), Text: text})
+			}
+			mu.Unlock()
+		}
+		// Drain so the process never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, reader)
+	}
+
+	wg.Add(2)
+	go collect(stdoutPipe, StreamStdout, &stdout)
+	go collect(stderrPipe, StreamStderr, &stderr)
+	wg.Wait()
+
+	err = cmd.Wait()
 
 	result := &ComposeResult{
 		Success: err == nil,
@@ -140,45 +292,25 @@ func runComposeCommand(
 	return result, nil
 }
 
-/*
-GetComposeProjectName extracts the actual project name from a compose file.
-Uses docker compose config to get the resolved project name.
-*/
-func GetComposeProjectName(composePath string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(
-		ctx,
-		"docker",
-		"compose",
-		"-f",
-		composePath,
-		"config",
-		"--format",
-		"json",
-	)
-
-	output, err := cmd.Output()
-	if err != nil {
-		dir := filepath.Dir(composePath)
-		return filepath.Base(dir)
+// scanOutputLines splits on \n or \r so progress redraws surface as they
+// happen instead of accumulating into one long line.
+func scanOutputLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
 	}
 
-	var config struct {
-		Name string `json:"name"`
-	}
-	if err := json.Unmarshal(output, &config); err != nil {
-		dir := filepath.Dir(composePath)
-		return filepath.Base(dir)
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		advance := i + 1
+		if data[i] == '\r' && advance < len(data) && data[advance] == '\n' {
+			advance++
+		}
+		return advance, data[:i], nil
 	}
 
-	if config.Name == "" {
-		dir := filepath.Dir(composePath)
-		return filepath.Base(dir)
+	if atEOF {
+		return len(data), data, nil
 	}
-
-	return config.Name
+	return 0, nil, nil
 }
 
 /*
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	cerrdefs "github.com/containerd/errdefs"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 
@@ -42,7 +43,9 @@ func (c *Client) ListContainers(
 
 	result := make([]model.Container, 0, len(containers))
 	for _, ctr := range containers {
-		result = append(result, containerToProject(ctr))
+		converted := containerToProject(ctr)
+		converted.Host = c.endpoint.Name
+		result = append(result, converted)
 	}
 
 	return result, nil
@@ -62,6 +65,7 @@ func (c *Client) GetContainer(
 	}
 
 	ctr := inspectToProject(info)
+	ctr.Host = c.endpoint.Name
 	return &ctr, nil
 }
 
@@ -108,6 +112,54 @@ func (c *Client) RestartContainer(
 	return nil
 }
 
+// KillContainer sends a signal to a running container. An empty signal
+// lets the daemon use its default, SIGKILL.
+func (c *Client) KillContainer(
+	ctx context.Context,
+	containerID, signal string,
+) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.cli.ContainerKill(ctx, containerID, signal); err != nil {
+		return fmt.Errorf("killing container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// PauseContainer suspends all processes in a container.
+func (c *Client) PauseContainer(ctx context.Context, containerID string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.cli.ContainerPause(ctx, containerID); err != nil {
+		return fmt.Errorf("pausing container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// UnpauseContainer resumes a paused container.
+func (c *Client) UnpauseContainer(ctx context.Context, containerID string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.cli.ContainerUnpause(ctx, containerID); err != nil {
+		return fmt.Errorf("unpausing container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// IsNotFound reports whether err means the daemon has no such object.
+func IsNotFound(err error) bool {
+	return cerrdefs.IsNotFound(err)
+}
+
+// IsConflict reports whether err means the object is in the wrong state
+// for the request, e.g. pausing a stopped container.
+func IsConflict(err error) bool {
+	return cerrdefs.IsConflict(err)
+}
+
 // RemoveContainer removes a container, optionally forcing removal of running containers.
 func (c *Client) RemoveContainer(
 	ctx context.Context,
@@ -182,6 +234,7 @@ func containerToProject(ctr container.Summary) model.Container {
 		Name:        name,
 		ServiceName: ctr.Labels["com.docker.compose.service"],
 		Image:       ctr.Image,
+		ImageID:     ctr.ImageID,
 		Status:      ctr.Status,
 		State:       state,
 		Health:      health,
@@ -255,6 +308,7 @@ func inspectToProject(info container.InspectResponse) model.Container {
 		Name:        name,
 		ServiceName: labels["com.docker.compose.service"],
 		Image:       image,
+		ImageID:     info.Image,
 		Status:      status,
 		State:       state,
 		Health:      health,
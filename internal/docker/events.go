@@ -0,0 +1,136 @@
+/*
+AngelaMos | 2026
+events.go
+*/
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+type Event struct {
+	Host       string            `json:"host"`
+	Type       string            `json:"type"`
+	Action     string            `json:"action"`
+	ActorID    string            `json:"actor_id"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Time       time.Time         `json:"time"`
+}
+
+// ComposeProject returns the compose project label carried by the event.
+// Container events include all container labels as attributes.
+func (e Event) ComposeProject() string {
+	return e.Attributes["com.docker.compose.project"]
+}
+
+// IsContainer reports whether the event is about a container itself.
+func (e Event) IsContainer() bool {
+	return e.Type == string(events.ContainerEventType)
+}
+
+// ContainerID returns the container the event refers to.
+// Network and volume events reference it through the container attribute.
+func (e Event) ContainerID() string {
+	if e.Type == string(events.ContainerEventType) {
+		return e.ActorID
+	}
+	return e.Attributes["container"]
+}
+
+// AffectsState reports whether the event can change project state.
+// Noisy actions like exec, attach or top are ignored.
+func (e Event) AffectsState() bool {
+	switch e.Type {
+	case string(events.ContainerEventType):
+		if strings.HasPrefix(e.Action, string(events.ActionHealthStatus)) {
+			return true
+		}
+		switch events.Action(e.Action) {
+		case events.ActionCreate, events.ActionStart, events.ActionRestart,
+			events.ActionStop, events.ActionDie, events.ActionKill,
+			events.ActionOOM, events.ActionPause, events.ActionUnPause,
+			events.ActionRename, events.ActionDestroy:
+			return true
+		}
+	case string(events.NetworkEventType):
+		switch events.Action(e.Action) {
+		case events.ActionConnect, events.ActionDisconnect:
+			return true
+		}
+	case string(events.VolumeEventType):
+		switch events.Action(e.Action) {
+		case events.ActionMount, events.ActionUnmount:
+			return true
+		}
+	}
+	return false
+}
+
+// Events subscribes to the daemon's event stream for containers, networks
+// and volumes. Both channels close when the context is cancelled or the
+// stream breaks; callers should resubscribe after an error.
+func (c *Client) Events(ctx context.Context) (<-chan Event, <-chan error) {
+	eventCh := make(chan Event, 64)
+	errCh := make(chan error, 1)
+
+	c.mu.RLock()
+	cli := c.cli
+	c.mu.RUnlock()
+
+	opts := events.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("type", string(events.NetworkEventType)),
+			filters.Arg("type", string(events.VolumeEventType)),
+		),
+	}
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		msgCh, streamErrCh := cli.Events(ctx, opts)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case err := <-streamErrCh:
+				if err != nil && ctx.Err() == nil {
+					errCh <- fmt.Errorf(
+						"event stream for %s: %w",
+						c.endpoint.Name,
+						err,
+					)
+				}
+				return
+
+			case msg := <-msgCh:
+				ev := Event{
+					Host:       c.endpoint.Name,
+					Type:       string(msg.Type),
+					Action:     string(msg.Action),
+					ActorID:    msg.Actor.ID,
+					Attributes: msg.Actor.Attributes,
+					Time:       time.Unix(0, msg.TimeNano),
+				}
+
+				select {
+				case eventCh <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return eventCh, errCh
+}
@@ -6,10 +6,14 @@ logs.go
 package docker
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/stdcopy"
@@ -20,6 +24,22 @@ type LogOutput struct {
 	Stderr string `json:"stderr"`
 }
 
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+
+	maxLogLineSize = 1024 * 1024
+)
+
+type LogLine struct {
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"line"`
+}
+
+// LineFunc receives output lines as they are produced.
+type LineFunc func(LogLine)
+
 type LogOptions struct {
 	Tail       string
 	Since      string
@@ -85,30 +105,34 @@ func (c *Client) GetLogs(
 	}, nil
 }
 
-// StreamLogs streams logs from a container in real-time.
-// Returns separate channels for stdout and stderr.
+// StreamLogs follows logs from a container in real-time, one line per
+// message. Lines always carry the daemon timestamp so clients can order them.
 func (c *Client) StreamLogs(
 	ctx context.Context,
 	containerID string,
 	opts LogOptions,
-) (<-chan string, <-chan string, <-chan error) {
-	stdoutCh := make(chan string, 100)
-	stderrCh := make(chan string, 100)
+) (<-chan LogLine, <-chan error) {
+	lineCh := make(chan LogLine, 100)
 	errCh := make(chan error, 1)
 
 	go func() {
-		defer close(stdoutCh)
-		defer close(stderrCh)
+		defer close(lineCh)
 		defer close(errCh)
 
 		c.mu.RLock()
 		cli := c.cli
 		c.mu.RUnlock()
 
+		info, err := cli.ContainerInspect(ctx, containerID)
+		if err != nil {
+			errCh <- fmt.Errorf("inspecting container for TTY: %w", err)
+			return
+		}
+
 		logOpts := container.LogsOptions{
 			ShowStdout: true,
 			ShowStderr: true,
-			Timestamps: opts.Timestamps,
+			Timestamps: true,
 			Follow:     true,
 			Tail:       opts.Tail,
 			Since:      opts.Since,
@@ -125,47 +149,62 @@ func (c *Client) StreamLogs(
 		}
 		defer func() { _ = reader.Close() }()
 
-		info, err := cli.ContainerInspect(ctx, containerID)
-		if err != nil {
-			errCh <- fmt.Errorf("inspecting container for TTY: %w", err)
+		if info.Config != nil && info.Config.Tty {
+			scanLogLines(ctx, reader, StreamStdout, lineCh)
 			return
 		}
 
-		isTTY := info.Config != nil && info.Config.Tty
+		streamMultiplexedLogs(ctx, reader, lineCh)
+	}()
+
+	return lineCh, errCh
+}
 
-		if isTTY {
-			streamTTYLogs(ctx, reader, stdoutCh)
-		} else {
-			streamMultiplexedLogs(ctx, reader, stdoutCh, stderrCh)
+// ParseLogLine splits the RFC3339Nano timestamp prefix docker adds when
+// timestamps are requested. Lines without a valid prefix are kept as-is.
+func ParseLogLine(stream, raw string) LogLine {
+	raw = strings.TrimSuffix(raw, "\r")
+	line := LogLine{Stream: stream, Text: raw}
+
+	if idx := strings.IndexByte(raw, ' '); idx > 0 {
+		if ts, err := time.Parse(time.RFC3339Nano, raw[:idx]); err == nil {
+			line.Timestamp = ts
+			line.Text = raw[idx+1:]
 		}
-	}()
+	}
 
-	return stdoutCh, stderrCh, errCh
+	return line
 }
 
-func streamTTYLogs(
+// SplitLogLines parses buffered log output into lines.
+func SplitLogLines(stream, output string) []LogLine {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return nil
+	}
+
+	raw := strings.Split(output, "\n")
+	lines := make([]LogLine, 0, len(raw))
+	for _, r := range raw {
+		lines = append(lines, ParseLogLine(stream, r))
+	}
+	return lines
+}
+
+func scanLogLines(
 	ctx context.Context,
 	reader io.Reader,
-	stdoutCh chan<- string,
+	stream string,
+	lineCh chan<- LogLine,
 ) {
-	buf := make([]byte, 4096)
-	for {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+
+	for scanner.Scan() {
 		select {
+		case lineCh <- ParseLogLine(stream, scanner.Text()):
 		case <-ctx.Done():
 			return
-		default:
-		}
-
-		n, err := reader.Read(buf)
-		if n > 0 {
-			select {
-			case stdoutCh <- string(buf[:n]):
-			case <-ctx.Done():
-				return
-			}
-		}
-		if err != nil {
-			return
 		}
 	}
 }
@@ -173,7 +212,7 @@ func streamTTYLogs(
 func streamMultiplexedLogs(
 	ctx context.Context,
 	reader io.Reader,
-	stdoutCh, stderrCh chan<- string,
+	lineCh chan<- LogLine,
 ) {
 	stdoutPR, stdoutPW := io.Pipe()
 	stderrPR, stderrPW := io.Pipe()
@@ -184,45 +223,21 @@ func streamMultiplexedLogs(
 		_, _ = stdcopy.StdCopy(stdoutPW, stderrPW, reader)
 	}()
 
-	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
 
+	// Closing the read side unblocks StdCopy if a scanner exits early.
 	go func() {
-		buf := make([]byte, 4096)
-		for {
-			n, err := stdoutPR.Read(buf)
-			if n > 0 {
-				select {
-				case stdoutCh <- string(buf[:n]):
-				case <-ctx.Done():
-					return
-				}
-			}
-			if err != nil {
-				return
-			}
-		}
+		defer wg.Done()
+		defer func() { _ = stdoutPR.Close() }()
+		scanLogLines(ctx, stdoutPR, StreamStdout, lineCh)
 	}()
 
 	go func() {
-		defer close(done)
-		buf := make([]byte, 4096)
-		for {
-			n, err := stderrPR.Read(buf)
-			if n > 0 {
-				select {
-				case stderrCh <- string(buf[:n]):
-				case <-ctx.Done():
-					return
-				}
-			}
-			if err != nil {
-				return
-			}
-		}
+		defer wg.Done()
+		defer func() { _ = stderrPR.Close() }()
+		scanLogLines(ctx, stderrPR, StreamStderr, lineCh)
 	}()
 
-	select {
-	case <-ctx.Done():
-	case <-done:
-	}
+	wg.Wait()
 }
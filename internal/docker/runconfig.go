@@ -0,0 +1,138 @@
+/*
+AngelaMos | 2026
+runconfig.go
+*/
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+// RunConfig is the configuration a container was created with, as far as
+// it can be compared with the compose service it was created from.
+type RunConfig struct {
+	Image      string
+	Env        map[string]string
+	Command    []string
+	Entrypoint []string
+	// Ports are the requested bindings as [host_ip:]published:target/proto,
+	// with published left out when the daemon picks the port.
+	Ports  []string
+	Mounts []RunMount
+	Labels map[string]string
+}
+
+// RunMount is a mount of a container. Source is the volume name for
+// volumes and the host path for binds.
+type RunMount struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// InspectRunConfig returns the configuration a container was created
+// with.
+func (c *Client) InspectRunConfig(
+	ctx context.Context,
+	containerID string,
+) (*RunConfig, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting container %s: %w", containerID, err)
+	}
+
+	config := &RunConfig{
+		Env:    make(map[string]string),
+		Ports:  make([]string, 0),
+		Mounts: make([]RunMount, 0, len(info.Mounts)),
+		Labels: make(map[string]string),
+	}
+
+	if info.Config != nil {
+		config.Image = info.Config.Image
+		config.Command = info.Config.Cmd
+		config.Entrypoint = info.Config.Entrypoint
+		for _, entry := range info.Config.Env {
+			name, value, _ := strings.Cut(entry, "=")
+			config.Env[name] = value
+		}
+		if info.Config.Labels != nil {
+			config.Labels = info.Config.Labels
+		}
+	}
+
+	if info.HostConfig != nil {
+		for port, bindings := range info.HostConfig.PortBindings {
+			for _, binding := range bindings {
+				config.Ports = append(config.Ports, FormatPort(
+					binding.HostIP,
+					binding.HostPort,
+					port.Port(),
+					port.Proto(),
+				))
+			}
+		}
+		sort.Strings(config.Ports)
+	}
+
+	for _, mount := range info.Mounts {
+		source := mount.Source
+		if mount.Name != "" {
+			source = mount.Name
+		}
+		config.Mounts = append(config.Mounts, RunMount{
+			Type:     string(mount.Type),
+			Source:   source,
+			Target:   mount.Destination,
+			ReadOnly: !mount.RW,
+		})
+	}
+
+	return config, nil
+}
+
+// FormatPort formats a port binding the way RunConfig reports them.
+// Unspecified host addresses are left out.
+func FormatPort(hostIP, published, target, protocol string) string {
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	spec := target + "/" + protocol
+	if published != "" {
+		spec = published + ":" + spec
+	}
+	if hostIP != "" && hostIP != "0.0.0.0" && hostIP != "::" {
+		spec = hostIP + ":" + spec
+	}
+	return spec
+}
+
+// TaggedImages maps every image tag on the host to the ID of the image it
+// currently points at.
+func (c *Client) TaggedImages(ctx context.Context) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	images, err := c.cli.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing images: %w", err)
+	}
+
+	tagged := make(map[string]string)
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			tagged[tag] = img.ID
+		}
+	}
+	return tagged, nil
+}
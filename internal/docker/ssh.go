@@ -0,0 +1,119 @@
+/*
+AngelaMos | 2026
+ssh.go
+*/
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// newSSHDialer returns a dialer that tunnels the Docker API over ssh.
+// Mirrors the docker CLI connection helper: runs
+// `ssh <host> docker system dial-stdio` and speaks HTTP over its stdio.
+func newSSHDialer(
+	host string,
+) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ssh host: %w", err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("ssh host %q has no hostname", host)
+	}
+
+	args := make([]string, 0, 8)
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	if u.Port() != "" {
+		args = append(args, "-p", u.Port())
+	}
+	args = append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		// The dial context only bounds connection setup, so the tunnel
+		// process must outlive it.
+		cmd := exec.Command("ssh", args...)
+
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("starting ssh tunnel: %w", err)
+		}
+
+		return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+	}, nil
+}
+
+// commandConn adapts a process's stdin/stdout to net.Conn.
+type commandConn struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    io.ReadCloser
+	closeOnce sync.Once
+}
+
+func (c *commandConn) Read(p []byte) (int, error) {
+	return c.stdout.Read(p)
+}
+
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		_ = c.stdin.Close()
+		if c.cmd.Process != nil {
+			_ = c.cmd.Process.Kill()
+		}
+		_ = c.cmd.Wait()
+	})
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr {
+	return commandAddr{}
+}
+
+func (c *commandConn) RemoteAddr() net.Addr {
+	return commandAddr{}
+}
+
+func (c *commandConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (c *commandConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *commandConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+type commandAddr struct{}
+
+func (commandAddr) Network() string {
+	return "ssh"
+}
+
+func (commandAddr) String() string {
+	return "ssh"
+}
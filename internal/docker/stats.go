@@ -21,6 +21,7 @@ import (
 type StatsCollector struct {
 	client    *Client
 	prevStats map[string]*container.StatsResponse
+	latest    map[string]*model.ContainerStats
 	mu        sync.RWMutex
 }
 
@@ -29,6 +30,7 @@ func NewStatsCollector(client *Client) *StatsCollector {
 	return &StatsCollector{
 		client:    client,
 		prevStats: make(map[string]*container.StatsResponse),
+		latest:    make(map[string]*model.ContainerStats),
 	}
 }
 
@@ -56,9 +58,13 @@ func (s *StatsCollector) GetStats(
 	s.mu.Lock()
 	prev := s.prevStats[containerID]
 	s.prevStats[containerID] = &stats
+
+	calculated := calculateStats(prev, &stats)
+	calculated.Host = s.client.Name()
+	s.latest[containerID] = calculated
 	s.mu.Unlock()
 
-	return calculateStats(prev, &stats), nil
+	return calculated, nil
 }
 
 // StreamStats continuously streams stats for a container.
@@ -105,6 +111,7 @@ func (s *StatsCollector) StreamStats(
 			}
 
 			calculated := calculateStats(prev, &stats)
+			calculated.Host = s.client.Name()
 			prev = &stats
 
 			select {
@@ -201,5 +208,14 @@ func calculateBlockIO(stats *container.StatsResponse) (read, write uint64) {
 func (s *StatsCollector) ClearPreviousStats(containerID string) {
 	s.mu.Lock()
 	delete(s.prevStats, containerID)
+	delete(s.latest, containerID)
 	s.mu.Unlock()
 }
+
+// Latest returns the most recent stats computed by GetStats for a container,
+// or nil if none were collected yet.
+func (s *StatsCollector) Latest(containerID string) *model.ContainerStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest[containerID]
+}
@@ -0,0 +1,488 @@
+/*
+AngelaMos | 2026
+jobs.go
+*/
+
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// maxOutputSize caps captured output per job; compose pulls can be chatty.
+const maxOutputSize = 256 * 1024
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobFinished  = errors.New("job already finished")
+	ErrJobCancelled = errors.New("job cancelled")
+)
+
+// Func does the work of a job. Anything written to output is captured
+// and reported with the job.
+type Func func(ctx context.Context, output *Output) error
+
+// Line is a single line of job output, published as it is produced.
+type Line struct {
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"line"`
+}
+
+// Subscription receives a job's output lines until the job finishes.
+// Job is the state at subscription time; its output holds every line
+// produced before the subscription. Lines are dropped if the subscriber
+// falls behind; Dropped reports how many.
+type Subscription struct {
+	Job     *Job
+	Lines   <-chan Line
+	Done    <-chan struct{}
+	close   func()
+	dropped func() int
+}
+
+// Close stops delivery of further lines.
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// Dropped returns how many lines were dropped because the subscriber fell
+// behind since the last call.
+func (s *Subscription) Dropped() int {
+	return s.dropped()
+}
+
+// Spec describes a job to submit.
+type Spec struct {
+	Kind      string
+	ProjectID string
+	// Key serializes jobs: at most one job per key runs at a time, in
+	// submission order. Empty keys are not serialized.
+	Key    string
+	Params map[string]any
+	Actor  string
+	Run    Func
+	// OnCancel, if set, is called instead of Run when the job is
+	// cancelled before it starts.
+	OnCancel func()
+}
+
+// Job is a point-in-time view of a job's state.
+type Job struct {
+	ID         string         `json:"id"`
+	Kind       string         `json:"kind"`
+	ProjectID  string         `json:"project_id,omitempty"`
+	Params     map[string]any `json:"params,omitempty"`
+	Actor      string         `json:"actor,omitempty"`
+	State      State          `json:"state"`
+	Output     string         `json:"output,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// Done reports whether the job reached a final state.
+func (j *Job) Done() bool {
+	return j.State == StateSucceeded ||
+		j.State == StateFailed ||
+		j.State == StateCancelled
+}
+
+type job struct {
+	Job
+	key         string
+	run         Func
+	onCancel    func()
+	output      strings.Builder
+	truncated   bool
+	cancel      context.CancelFunc
+	cancelled   bool
+	done        chan struct{}
+	subscribers map[chan Line]int
+}
+
+type Runner struct {
+	ctx       context.Context
+	workers   int
+	retention time.Duration
+	jobs      map[string]*job
+	pending   []*job
+	busy      map[string]bool
+	running   int
+	onUpdate  func(*Job)
+	onOutput  func(*Job, Line)
+	logger    *slog.Logger
+	mu        sync.Mutex
+}
+
+// NewRunner creates a job runner executing at most workers jobs at once.
+// Jobs run under ctx, so cancelling it cancels every running job.
+// Finished jobs are forgotten after retention.
+func NewRunner(
+	ctx context.Context,
+	workers int,
+	retention time.Duration,
+	logger *slog.Logger,
+) *Runner {
+	if workers <= 0 {
+		workers = 4
+	}
+	if retention <= 0 {
+		retention = time.Hour
+	}
+
+	return &Runner{
+		ctx:       ctx,
+		workers:   workers,
+		retention: retention,
+		jobs:      make(map[string]*job),
+		busy:      make(map[string]bool),
+		logger:    logger,
+	}
+}
+
+// OnUpdate registers a callback invoked on every job state change.
+// It is called with the runner lock held so updates arrive in order; it
+// must be fast and must not call back into the runner.
+func (r *Runner) OnUpdate(fn func(*Job)) {
+	r.mu.Lock()
+	r.onUpdate = fn
+	r.mu.Unlock()
+}
+
+// OnOutput registers a callback invoked for every output line. The job
+// passed carries no output. Like OnUpdate it runs under the runner lock.
+func (r *Runner) OnOutput(fn func(*Job, Line)) {
+	r.mu.Lock()
+	r.onOutput = fn
+	r.mu.Unlock()
+}
+
+// Submit queues a job and returns its initial state.
+func (r *Runner) Submit(spec Spec) (*Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	j := &job{
+		Job: Job{
+			ID:        id,
+			Kind:      spec.Kind,
+			ProjectID: spec.ProjectID,
+			Params:    spec.Params,
+			Actor:     spec.Actor,
+			State:     StateQueued,
+			CreatedAt: time.Now(),
+		},
+		key:         spec.Key,
+		run:         spec.Run,
+		onCancel:    spec.OnCancel,
+		done:        make(chan struct{}),
+		subscribers: make(map[chan Line]int),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pruneLocked()
+	r.jobs[id] = j
+	r.pending = append(r.pending, j)
+	snapshot := j.snapshot()
+	r.notifyLocked(snapshot)
+	r.dispatchLocked()
+
+	return &snapshot, nil
+}
+
+// Get returns the current state of a job.
+func (r *Runner) Get(id string) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	snapshot := j.snapshot()
+	return &snapshot, nil
+}
+
+// Subscribe follows a job's output. Done is closed once the job reaches
+// a final state, which may already be the case.
+func (r *Runner) Subscribe(id string) (*Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	ch := make(chan Line, 256)
+	j.subscribers[ch] = 0
+	snapshot := j.snapshot()
+
+	return &Subscription{
+		Job:   &snapshot,
+		Lines: ch,
+		Done:  j.done,
+		close: func() {
+			r.mu.Lock()
+			delete(j.subscribers, ch)
+			r.mu.Unlock()
+		},
+		dropped: func() int {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			n := j.subscribers[ch]
+			if n > 0 {
+				j.subscribers[ch] = 0
+			}
+			return n
+		},
+	}, nil
+}
+
+// List returns known jobs, newest first, optionally for one project.
+// Output is omitted to keep listings small.
+func (r *Runner) List(projectID string) []*Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*Job, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		if projectID != "" && j.ProjectID != projectID {
+			continue
+		}
+		snapshot := j.snapshot()
+		snapshot.Output = ""
+		list = append(list, &snapshot)
+	}
+
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].CreatedAt.After(list[b].CreatedAt)
+	})
+	return list
+}
+
+// Cancel stops a job. Queued jobs are cancelled immediately and their
+// OnCancel is called; running jobs have their context cancelled and
+// finish as cancelled once they return.
+func (r *Runner) Cancel(id string) (*Job, error) {
+	var onCancel func()
+	defer func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	}()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	switch j.State {
+	case StateQueued:
+		for i, p := range r.pending {
+			if p == j {
+				r.pending = append(r.pending[:i], r.pending[i+1:]...)
+				break
+			}
+		}
+		now := time.Now()
+		j.State = StateCancelled
+		j.FinishedAt = &now
+		close(j.done)
+		r.notifyLocked(j.snapshot())
+		onCancel = j.onCancel
+	case StateRunning:
+		j.cancelled = true
+		j.cancel()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrJobFinished, id)
+	}
+
+	snapshot := j.snapshot()
+	return &snapshot, nil
+}
+
+// dispatchLocked starts as many pending jobs as free workers and key
+// serialization allow.
+func (r *Runner) dispatchLocked() {
+	remaining := r.pending[:0]
+	for _, j := range r.pending {
+		if r.running >= r.workers || (j.key != "" && r.busy[j.key]) {
+			remaining = append(remaining, j)
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(r.ctx)
+		now := time.Now()
+		j.cancel = cancel
+		j.State = StateRunning
+		j.StartedAt = &now
+
+		r.running++
+		if j.key != "" {
+			r.busy[j.key] = true
+		}
+
+		r.notifyLocked(j.snapshot())
+		go r.execute(ctx, j)
+	}
+	r.pending = remaining
+}
+
+func (r *Runner) execute(ctx context.Context, j *job) {
+	err := j.run(ctx, &Output{runner: r, job: j})
+	j.cancel()
+
+	r.mu.Lock()
+	now := time.Now()
+	j.FinishedAt = &now
+
+	switch {
+	case err != nil && (j.cancelled || ctx.Err() != nil):
+		j.State = StateCancelled
+		j.Error = err.Error()
+	case err != nil:
+		j.State = StateFailed
+		j.Error = err.Error()
+	default:
+		j.State = StateSucceeded
+	}
+
+	r.running--
+	if j.key != "" {
+		delete(r.busy, j.key)
+	}
+
+	state := j.State
+	close(j.done)
+	r.notifyLocked(j.snapshot())
+	r.dispatchLocked()
+	r.mu.Unlock()
+
+	if err != nil {
+		r.logger.Warn("job finished with error",
+			"job", j.ID,
+			"kind", j.Kind,
+			"project", j.ProjectID,
+			"state", state,
+			"error", err,
+		)
+	}
+}
+
+// pruneLocked forgets finished jobs older than the retention period.
+func (r *Runner) pruneLocked() {
+	cutoff := time.Now().Add(-r.retention)
+	for id, j := range r.jobs {
+		if j.FinishedAt != nil && j.FinishedAt.Before(cutoff) {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+func (r *Runner) notifyLocked(j Job) {
+	if r.onUpdate != nil {
+		r.onUpdate(&j)
+	}
+}
+
+// snapshot copies the job's public state. Callers hold the runner lock.
+func (j *job) snapshot() Job {
+	s := j.Job
+	s.Output = j.output.String()
+	if j.truncated {
+		s.Output += "\n[output truncated]"
+	}
+	return s
+}
+
+// Output captures a job's output. It is an io.Writer for unstructured
+// output; Line additionally publishes the line to listeners.
+type Output struct {
+	runner *Runner
+	job    *job
+}
+
+func (o *Output) Write(p []byte) (int, error) {
+	o.runner.mu.Lock()
+	defer o.runner.mu.Unlock()
+
+	o.appendLocked(p)
+	return len(p), nil
+}
+
+// Line records a line of output and publishes it to subscribers.
+func (o *Output) Line(line Line) {
+	if line.Timestamp.IsZero() {
+		line.Timestamp = time.Now()
+	}
+
+	o.runner.mu.Lock()
+	defer o.runner.mu.Unlock()
+
+	o.appendLocked([]byte(line.Text + "\n"))
+
+	for ch := range o.job.subscribers {
+		select {
+		case ch <- line:
+		default:
+			// Counted until the subscriber asks; see Subscription.Dropped.
+			o.job.subscribers[ch]++
+		}
+	}
+
+	if o.runner.onOutput != nil {
+		header := o.job.Job
+		o.runner.onOutput(&header, line)
+	}
+}
+
+func (o *Output) appendLocked(p []byte) {
+	room := maxOutputSize - o.job.output.Len()
+	if room <= 0 {
+		o.job.truncated = true
+		return
+	}
+	if len(p) > room {
+		p = p[:room]
+		o.job.truncated = true
+	}
+	o.job.output.Write(p)
+}
+
+func newID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating job id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,130 @@
+/*
+AngelaMos | 2026
+exposition.go
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the Prometheus text exposition format content type.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type sample struct {
+	labels []Label
+	value  float64
+}
+
+// Family is a metric with its help text and samples, written as one block.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	samples []sample
+}
+
+// NewGauge creates a gauge metric family.
+func NewGauge(name, help string) *Family {
+	return &Family{Name: name, Help: help, Type: "gauge"}
+}
+
+// NewCounter creates a counter metric family.
+func NewCounter(name, help string) *Family {
+	return &Family{Name: name, Help: help, Type: "counter"}
+}
+
+// Add appends a sample with label pairs given as name, value, name, value...
+func (f *Family) Add(value float64, labelPairs ...string) {
+	labels := make([]Label, 0, len(labelPairs)/2)
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		labels = append(labels, Label{Name: labelPairs[i], Value: labelPairs[i+1]})
+	}
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// WriteTo writes the family in text exposition format.
+// Families without samples are omitted entirely.
+func (f *Family) WriteTo(w io.Writer) (int64, error) {
+	if len(f.samples) == 0 {
+		return 0, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+	fmt.Fprintf(&b, "# TYPE %s %s\n", f.Name, f.Type)
+	for _, s := range f.samples {
+		writeSample(&b, f.Name, s.labels, s.value)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func writeSample(b *strings.Builder, name string, labels []Label, value float64) {
+	b.WriteString(name)
+
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l.Name)
+			b.WriteString(`="`)
+			b.WriteString(escapeLabel(l.Value))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte('\n')
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+// sortedKeys returns map keys in a stable order for deterministic output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+/*
+AngelaMos | 2026
+http.go
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the request duration
+// histogram. Compose operations can take minutes, hence the long tail.
+var durationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300,
+}
+
+type requestKey struct {
+	method string
+	route  string
+	status string
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// HTTPMetrics records request counts and latencies per route pattern.
+type HTTPMetrics struct {
+	requests  map[requestKey]uint64
+	durations map[string]*histogram
+	mu        sync.Mutex
+}
+
+// NewHTTPMetrics creates an empty HTTP metrics recorder.
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// Observe records a completed request. Route should be the router pattern,
+// not the raw path, to keep label cardinality bounded.
+func (m *HTTPMetrics) Observe(
+	method, route string,
+	status int,
+	duration time.Duration,
+) {
+	if m == nil {
+		return
+	}
+
+	seconds := duration.Seconds()
+	key := requestKey{method: method, route: route, status: strconv.Itoa(status)}
+	histKey := method + " " + route
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[key]++
+
+	h, ok := m.durations[histKey]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.durations[histKey] = h
+	}
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// WriteTo writes request counters and the duration histogram.
+func (m *HTTPMetrics) WriteTo(w io.Writer) (int64, error) {
+	if m == nil {
+		return 0, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := NewCounter(
+		"holophyly_http_requests_total",
+		"HTTP requests served, by method, route and status.",
+	)
+	for key, count := range m.requests {
+		requests.Add(float64(count),
+			"method", key.method,
+			"route", key.route,
+			"status", key.status,
+		)
+	}
+
+	written, err := requests.WriteTo(w)
+	if err != nil || len(m.durations) == 0 {
+		return written, err
+	}
+
+	var b strings.Builder
+	const name = "holophyly_http_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s HTTP request latency by method and route.\n", name)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+
+	for _, histKey := range sortedKeys(m.durations) {
+		h := m.durations[histKey]
+		method, route, _ := strings.Cut(histKey, " ")
+		base := []Label{{Name: "method", Value: method}, {Name: "route", Value: route}}
+
+		for i, bound := range durationBuckets {
+			labels := append(base[:2:2], Label{Name: "le", Value: formatValue(bound)})
+			writeSample(&b, name+"_bucket", labels, float64(h.counts[i]))
+		}
+		labels := append(base[:2:2], Label{Name: "le", Value: "+Inf"})
+		writeSample(&b, name+"_bucket", labels, float64(h.count))
+		writeSample(&b, name+"_sum", base, h.sum)
+		writeSample(&b, name+"_count", base, float64(h.count))
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return written + int64(n), err
+}
@@ -34,29 +34,67 @@ const (
 	ProtectionAutoDetected     ProtectionReason = "auto_detected"
 )
 
+// ProjectSource tells how a project was discovered.
+type ProjectSource string
+
+const (
+	// SourceCompose projects come from a scanned compose file.
+	SourceCompose ProjectSource = "compose"
+	// SourceStandalone projects wrap a single container that does not
+	// belong to any compose project, e.g. one started with docker run.
+	SourceStandalone ProjectSource = "standalone"
+	// SourceExternal projects are running compose projects whose files
+	// lie outside the scan paths, rebuilt from their container labels.
+	SourceExternal ProjectSource = "external"
+)
+
 type Project struct {
 	ID               string           `json:"id"`
 	Name             string           `json:"name"`
+	Source           ProjectSource    `json:"source"`
 	DisplayName      string           `json:"display_name,omitempty"`
-	Path             string           `json:"path"`
-	ComposeFile      string           `json:"compose_file"`
-	ComposeFilePath  string           `json:"compose_file_path"`
+	Host             string           `json:"host"`
+	Path             string           `json:"path,omitempty"`
+	ComposeFile      string           `json:"compose_file,omitempty"`
+	ComposeFilePath  string           `json:"compose_file_path,omitempty"`
+	ComposeFiles     []string         `json:"compose_files,omitempty"`
+	AvailableFiles   []string         `json:"available_files,omitempty"`
+	Profiles         []string         `json:"profiles,omitempty"`
+	ActiveProfiles   []string         `json:"active_profiles,omitempty"`
+	EnvFiles         []string         `json:"env_files,omitempty"`
 	Environment      Environment      `json:"environment"`
 	Status           ProjectStatus    `json:"status"`
+	Drift            *ProjectDrift    `json:"drift,omitempty"`
 	Protected        bool             `json:"protected"`
 	ProtectionReason ProtectionReason `json:"protection_reason,omitempty"`
+	ProtectionRule   *ProtectionRule  `json:"protection_rule,omitempty"`
 	Hidden           bool             `json:"hidden"`
+	ReadOnly         bool             `json:"read_only,omitempty"`
 	Containers       []Container      `json:"containers"`
 	Services         []string         `json:"services"`
 	CreatedAt        time.Time        `json:"created_at"`
 	UpdatedAt        time.Time        `json:"updated_at"`
 }
 
+// ProtectionRule is an explicit, persisted protection decision. It takes
+// precedence over config and pattern detection; Protected false marks a
+// detected project as deliberately unprotected.
+type ProtectionRule struct {
+	Protected bool             `json:"protected"`
+	Reason    ProtectionReason `json:"reason,omitempty"`
+	Note      string           `json:"note,omitempty"`
+	SetBy     string           `json:"set_by,omitempty"`
+	SetAt     time.Time        `json:"set_at"`
+}
+
 type Container struct {
 	ID          string            `json:"id"`
 	Name        string            `json:"name"`
+	Host        string            `json:"host"`
+	ProjectID   string            `json:"project_id,omitempty"`
 	ServiceName string            `json:"service_name"`
 	Image       string            `json:"image"`
+	ImageID     string            `json:"image_id,omitempty"`
 	Status      string            `json:"status"`
 	State       string            `json:"state"`
 	Health      string            `json:"health,omitempty"`
@@ -84,9 +122,47 @@ type ContainerStats struct {
 	BlockRead     uint64    `json:"block_read"`
 	BlockWrite    uint64    `json:"block_write"`
 	PIDs          uint64    `json:"pids"`
+	Host          string    `json:"host,omitempty"`
 	Timestamp     time.Time `json:"timestamp"`
 }
 
+type StatsPoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	CPUPercent  float64   `json:"cpu_percent"`
+	MemoryUsage uint64    `json:"memory_usage"`
+	MemoryLimit uint64    `json:"memory_limit"`
+	NetworkRx   uint64    `json:"network_rx"`
+	NetworkTx   uint64    `json:"network_tx"`
+	BlockRead   uint64    `json:"block_read"`
+	BlockWrite  uint64    `json:"block_write"`
+	PIDs        uint64    `json:"pids"`
+}
+
+type StatsSeries struct {
+	ContainerID   string       `json:"container_id"`
+	ContainerName string       `json:"container_name"`
+	ServiceName   string       `json:"service_name"`
+	Host          string       `json:"host"`
+	Points        []StatsPoint `json:"points"`
+}
+
+type StatsHistory struct {
+	ProjectID  string        `json:"project_id"`
+	From       time.Time     `json:"from"`
+	To         time.Time     `json:"to"`
+	Step       int           `json:"step_seconds"`
+	Containers []StatsSeries `json:"containers"`
+	Project    []StatsPoint  `json:"project"`
+}
+
+type HostInfo struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	Default   bool   `json:"default"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
 type SystemInfo struct {
 	DockerVersion     string `json:"docker_version"`
 	APIVersion        string `json:"api_version"`
@@ -147,3 +223,141 @@ type PortCheck struct {
 	Process   string `json:"process,omitempty"`
 	PID       int    `json:"pid,omitempty"`
 }
+
+// EnvSource is where the value of a variable a compose file references
+// comes from.
+type EnvSource string
+
+const (
+	EnvSourceEnvironment EnvSource = "environment"
+	EnvSourceFile        EnvSource = "env_file"
+	EnvSourceDefault     EnvSource = "default"
+	EnvSourceUnset       EnvSource = "unset"
+)
+
+// EnvVariable is a variable referenced by a project's compose files.
+// Values of secret-looking variables are masked.
+type EnvVariable struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value,omitempty"`
+	Source   EnvSource `json:"source"`
+	File     string    `json:"file,omitempty"`
+	Required bool      `json:"required,omitempty"`
+	Masked   bool      `json:"masked,omitempty"`
+}
+
+// ProjectEnv describes the environment a project is interpolated with.
+type ProjectEnv struct {
+	EnvFiles          []string      `json:"env_files"`
+	AvailableEnvFiles []string      `json:"available_env_files"`
+	Variables         []EnvVariable `json:"variables"`
+	Unset             []string      `json:"unset"`
+}
+
+// IssueSeverity tells whether a config issue stops a project from
+// starting.
+type IssueSeverity string
+
+const (
+	IssueError   IssueSeverity = "error"
+	IssueWarning IssueSeverity = "warning"
+)
+
+// IssueKind is the check a config issue was found by.
+type IssueKind string
+
+const (
+	IssueInterpolation IssueKind = "interpolation"
+	IssueBuildContext  IssueKind = "build_context"
+	IssueUnknownKey    IssueKind = "unknown_key"
+	IssueInvalid       IssueKind = "invalid"
+)
+
+// ConfigIssue is a problem found validating a project's compose files.
+// Path locates it in the compose model, e.g. services.web.build.
+type ConfigIssue struct {
+	Severity IssueSeverity `json:"severity"`
+	Kind     IssueKind     `json:"kind"`
+	Path     string        `json:"path,omitempty"`
+	File     string        `json:"file,omitempty"`
+	Message  string        `json:"message"`
+}
+
+// ConfigValidation is the outcome of validating a project's compose
+// files. Valid is false if any issue is an error.
+type ConfigValidation struct {
+	Valid  bool          `json:"valid"`
+	Issues []ConfigIssue `json:"issues"`
+}
+
+// DriftStatus tells whether a project's running containers match its
+// compose files.
+type DriftStatus string
+
+const (
+	DriftInSync   DriftStatus = "in_sync"
+	DriftDetected DriftStatus = "drifted"
+)
+
+// ProjectDrift lists the services whose running containers differ from
+// what the compose files would create. It is only checked for scanned
+// projects with containers.
+type ProjectDrift struct {
+	Status    DriftStatus    `json:"status"`
+	Services  []ServiceDrift `json:"services"`
+	CheckedAt time.Time      `json:"checked_at"`
+}
+
+// ServiceDrift is how one container of a service differs from it.
+type ServiceDrift struct {
+	Service     string            `json:"service"`
+	Container   string            `json:"container"`
+	Differences []DriftDifference `json:"differences"`
+}
+
+// DriftDifference is a single setting that differs. Key names the entry
+// within it, such as a variable or a mount target; secret-looking values
+// are masked.
+type DriftDifference struct {
+	Field    string `json:"field"`
+	Key      string `json:"key,omitempty"`
+	Expected string `json:"expected"`
+	Running  string `json:"running"`
+}
+
+// EdgeKind is the compose setting a dependency between services comes
+// from.
+type EdgeKind string
+
+const (
+	EdgeDependsOn   EdgeKind = "depends_on"
+	EdgeLink        EdgeKind = "links"
+	EdgeNetworkMode EdgeKind = "network_mode"
+	EdgeVolumesFrom EdgeKind = "volumes_from"
+)
+
+// GraphNode is a service of a project with the state of its containers.
+type GraphNode struct {
+	Service    string        `json:"service"`
+	Status     ProjectStatus `json:"status"`
+	Health     string        `json:"health,omitempty"`
+	Containers int           `json:"containers"`
+}
+
+// GraphEdge says that From depends on To. Condition is the depends_on
+// condition To has to meet before From is started.
+type GraphEdge struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Kind      EdgeKind `json:"kind"`
+	Condition string   `json:"condition,omitempty"`
+	Required  bool     `json:"required"`
+}
+
+// ServiceGraph is the dependency graph of a project's services. Warnings
+// name cycles and references to services that are not defined.
+type ServiceGraph struct {
+	Nodes    []GraphNode `json:"nodes"`
+	Edges    []GraphEdge `json:"edges"`
+	Warnings []string    `json:"warnings"`
+}
@@ -0,0 +1,117 @@
+/*
+AngelaMos | 2026
+config.go
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ProjectConfig returns the resolved compose model a project runs with,
+// as docker compose config would print it, with secret-looking values
+// masked.
+func (m *Manager) ProjectConfig(
+	ctx context.Context,
+	id string,
+) (*types.Project, error) {
+	proj, target, err := m.configTarget(id)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := scanner.ResolveConfig(
+		ctx,
+		target.Files,
+		target.EnvFiles,
+		target.Profiles,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("loading config of %s: %w", proj.Name, err)
+	}
+	return config, nil
+}
+
+// ValidateProject checks a project's compose files before they are run.
+// If the checks find no errors, the files are also checked with docker
+// compose config on the project's host, which catches anything the
+// compose CLI there rejects.
+func (m *Manager) ValidateProject(
+	ctx context.Context,
+	id string,
+) (*model.ConfigValidation, error) {
+	proj, target, err := m.configTarget(id)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := scanner.ValidateConfig(
+		ctx,
+		target.Files,
+		target.EnvFiles,
+		target.Profiles,
+	)
+
+	valid := true
+	for _, issue := range issues {
+		if issue.Severity == model.IssueError {
+			valid = false
+		}
+	}
+
+	if valid {
+		h, err := m.host(proj.Host)
+		if err != nil {
+			return nil, err
+		}
+		result, err := h.client.ComposeConfig(ctx, target)
+		if err != nil {
+			message := err.Error()
+			if result != nil && result.Error != "" {
+				message = strings.TrimSpace(result.Error)
+			}
+			issues = append(issues, model.ConfigIssue{
+				Severity: model.IssueError,
+				Kind:     model.IssueInvalid,
+				Message:  message,
+			})
+			valid = false
+		}
+	}
+
+	return &model.ConfigValidation{Valid: valid, Issues: issues}, nil
+}
+
+// configTarget returns a project with the files it is loaded from, which
+// have to be readable.
+func (m *Manager) configTarget(id string) (*model.Project, docker.ComposeTarget, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, docker.ComposeTarget{}, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(proj.ComposeFiles) == 0 {
+		return nil, docker.ComposeTarget{}, fmt.Errorf(
+			"%w: %s has no compose files",
+			ErrUnsupported,
+			proj.Name,
+		)
+	}
+	if err := checkWritable(proj); err != nil {
+		return nil, docker.ComposeTarget{}, err
+	}
+
+	return proj, composeTarget(proj), nil
+}
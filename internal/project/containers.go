@@ -0,0 +1,298 @@
+/*
+AngelaMos | 2026
+containers.go
+*/
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ErrContainerNotFound is returned when no host knows a container.
+var ErrContainerNotFound = errors.New("container not found")
+
+// defaultStopTimeout matches the docker CLI's grace period.
+const defaultStopTimeout = 10 * time.Second
+
+// ContainerAction is a lifecycle operation on a single container.
+type ContainerAction string
+
+const (
+	ContainerStart   ContainerAction = "start"
+	ContainerStop    ContainerAction = "stop"
+	ContainerRestart ContainerAction = "restart"
+	ContainerKill    ContainerAction = "kill"
+	ContainerPause   ContainerAction = "pause"
+	ContainerUnpause ContainerAction = "unpause"
+	ContainerRemove  ContainerAction = "remove"
+)
+
+// guardedContainerActions disrupt a running container and so are refused
+// on protected containers unless forced.
+var guardedContainerActions = map[ContainerAction]bool{
+	ContainerStop:    true,
+	ContainerRestart: true,
+	ContainerKill:    true,
+	ContainerPause:   true,
+	ContainerRemove:  true,
+}
+
+// Valid reports whether the action is a known container action.
+func (a ContainerAction) Valid() bool {
+	switch a {
+	case ContainerStart, ContainerUnpause:
+		return true
+	}
+	return guardedContainerActions[a]
+}
+
+// Guarded reports whether protection applies to the action.
+func (a ContainerAction) Guarded() bool {
+	return guardedContainerActions[a]
+}
+
+// ContainerActionOptions tune a container action. Timeout applies to stop
+// and restart, Signal to kill. Force overrides protection and, for
+// remove, also removes a running container.
+type ContainerActionOptions struct {
+	Timeout time.Duration
+	Signal  string
+	Force   bool
+}
+
+// ListContainers returns every container on every reachable host, or on
+// hostName only, including ones that belong to no compose project.
+// Containers of known projects carry the project's ID.
+func (m *Manager) ListContainers(
+	ctx context.Context,
+	hostName string,
+) ([]model.Container, error) {
+	names := m.hostOrder
+	if hostName != "" {
+		if _, err := m.host(hostName); err != nil {
+			return nil, err
+		}
+		names = []string{hostName}
+	}
+
+	containers := []model.Container{}
+	var lastErr error
+	queried := 0
+
+	for _, name := range names {
+		list, err := m.hosts[name].client.ListContainers(ctx, "")
+		if err != nil {
+			lastErr = fmt.Errorf("host %s: %w", name, err)
+			continue
+		}
+		queried++
+		containers = append(containers, list...)
+	}
+
+	if queried == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	for i := range containers {
+		containers[i].ProjectID = m.projectIDForContainer(&containers[i])
+	}
+
+	return containers, nil
+}
+
+// InspectContainer returns full details of a container. hostName may be
+// empty, in which case the container is looked up on every host.
+func (m *Manager) InspectContainer(
+	ctx context.Context,
+	containerID, hostName string,
+) (*model.Container, error) {
+	_, ctr, err := m.locateContainer(ctx, containerID, hostName)
+	return ctr, err
+}
+
+// ContainerProtection reports whether a container is protected, either
+// because its project is or because its name or image matches a
+// protection pattern.
+func (m *Manager) ContainerProtection(
+	ctr *model.Container,
+) (bool, model.ProtectionReason) {
+	if ctr.ProjectID != "" {
+		if proj, err := m.GetProject(ctr.ProjectID); err == nil {
+			m.mu.RLock()
+			protected, reason := proj.Protected, proj.ProtectionReason
+			m.mu.RUnlock()
+			if protected {
+				return true, reason
+			}
+		}
+	}
+
+	if protected, reason := scanner.IsProtectedByPattern(ctr.Name); protected {
+		return true, reason
+	}
+	return scanner.IsProtectedByPattern(ctr.Image)
+}
+
+// RunContainerAction performs a lifecycle action on a container and
+// returns its state afterwards, or nil once removed. Disruptive actions
+// on protected containers are refused unless forced. The owning project,
+// if any, is refreshed.
+func (m *Manager) RunContainerAction(
+	ctx context.Context,
+	containerID, hostName string,
+	action ContainerAction,
+	opts ContainerActionOptions,
+) (*model.Container, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown container action: %s", action)
+	}
+
+	h, ctr, err := m.locateContainer(ctx, containerID, hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	if action.Guarded() && !opts.Force {
+		if protected, reason := m.ContainerProtection(ctr); protected {
+			return nil, fmt.Errorf(
+				"%w: container %s (%s) - use force to override",
+				ErrProjectProtected,
+				ctr.Name,
+				reason,
+			)
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultStopTimeout
+	}
+
+	switch action {
+	case ContainerStart:
+		err = h.client.StartContainer(ctx, ctr.ID)
+	case ContainerStop:
+		err = h.client.StopContainer(ctx, ctr.ID, timeout)
+	case ContainerRestart:
+		err = h.client.RestartContainer(ctx, ctr.ID, timeout)
+	case ContainerKill:
+		err = h.client.KillContainer(ctx, ctr.ID, opts.Signal)
+	case ContainerPause:
+		err = h.client.PauseContainer(ctx, ctr.ID)
+	case ContainerUnpause:
+		err = h.client.UnpauseContainer(ctx, ctr.ID)
+	case ContainerRemove:
+		err = h.client.RemoveContainer(ctx, ctr.ID, opts.Force)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ctr.ProjectID != "" {
+		_ = m.refreshProject(ctx, ctr.ProjectID)
+	}
+
+	if action == ContainerRemove {
+		return nil, nil
+	}
+
+	updated, err := h.client.GetContainer(ctx, ctr.ID)
+	if err != nil {
+		return nil, err
+	}
+	updated.ProjectID = ctr.ProjectID
+	return updated, nil
+}
+
+// locateContainer finds the host a container lives on. Containers of known
+// projects are looked up on their project's host; others are searched for
+// on every host in order.
+func (m *Manager) locateContainer(
+	ctx context.Context,
+	containerID, hostName string,
+) (*host, *model.Container, error) {
+	var candidates []string
+	switch {
+	case hostName != "":
+		if _, err := m.host(hostName); err != nil {
+			return nil, nil, err
+		}
+		candidates = []string{hostName}
+	default:
+		if known, ok := m.FindContainer(containerID); ok {
+			candidates = append(candidates, known.Host)
+		}
+		candidates = append(candidates, m.hostOrder...)
+	}
+
+	var lastErr error
+	tried := make(map[string]bool, len(candidates))
+
+	for _, name := range candidates {
+		h, ok := m.hosts[name]
+		if !ok || tried[name] {
+			continue
+		}
+		tried[name] = true
+
+		ctr, err := h.client.GetContainer(ctx, containerID)
+		if err != nil {
+			if !docker.IsNotFound(err) {
+				lastErr = fmt.Errorf("host %s: %w", name, err)
+			}
+			continue
+		}
+
+		ctr.ProjectID = m.projectIDForContainer(ctr)
+		return h, ctr, nil
+	}
+
+	if lastErr != nil {
+		return nil, nil, lastErr
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerID)
+}
+
+// projectIDForContainer maps a container to the known project on its host
+// that lists it, then by its compose project label, or to its standalone
+// project if it has no label. Projects on other hosts never match, as
+// compose project names and container IDs need not be unique across hosts.
+func (m *Manager) projectIDForContainer(ctr *model.Container) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for id, proj := range m.projects {
+		if proj.Host != ctr.Host {
+			continue
+		}
+		for _, known := range proj.Containers {
+			if known.ID == ctr.ID {
+				return id
+			}
+		}
+	}
+
+	composeName := ctr.Labels["com.docker.compose.project"]
+	if composeName == "" {
+		id := standaloneID(ctr.Host, ctr.Name)
+		if _, ok := m.projects[id]; ok {
+			return id
+		}
+		return ""
+	}
+
+	for id, name := range m.composeNames {
+		if proj, ok := m.projects[id]; ok && name == composeName && proj.Host == ctr.Host {
+			return id
+		}
+	}
+	return ""
+}
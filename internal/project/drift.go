@@ -0,0 +1,484 @@
+/*
+AngelaMos | 2026
+drift.go
+*/
+
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+const (
+	configHashLabel = "com.docker.compose.config-hash"
+	oneOffLabel     = "com.docker.compose.oneoff"
+)
+
+// driftCheck is what checking one project's drift needs, copied out of
+// the manager so docker can be queried without holding the lock.
+type driftCheck struct {
+	id         string
+	host       string
+	config     *types.Project
+	containers []model.Container
+}
+
+// runConfigCache keeps the run configs of a host's containers. A
+// container's configuration is fixed when it is created, so each one is
+// inspected once rather than on every drift check.
+type runConfigCache struct {
+	mu      sync.Mutex
+	configs map[string]*docker.RunConfig
+}
+
+func newRunConfigCache() *runConfigCache {
+	return &runConfigCache{configs: make(map[string]*docker.RunConfig)}
+}
+
+func (c *runConfigCache) get(
+	ctx context.Context,
+	client *docker.Client,
+	containerID string,
+) (*docker.RunConfig, error) {
+	c.mu.Lock()
+	run, ok := c.configs[containerID]
+	c.mu.Unlock()
+	if ok {
+		return run, nil
+	}
+
+	run, err := client.InspectRunConfig(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.configs[containerID] = run
+	c.mu.Unlock()
+	return run, nil
+}
+
+// retain drops the configs of containers that are not in live.
+func (c *runConfigCache) retain(live map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id := range c.configs {
+		if !live[id] {
+			delete(c.configs, id)
+		}
+	}
+}
+
+// checkDrift compares the containers of the given projects with the
+// compose models they were last scanned as and records the outcome on
+// each project. Projects that are not scanned or have no containers are
+// left without drift. A project whose containers cannot be inspected
+// keeps its previous result.
+func (m *Manager) checkDrift(ctx context.Context, ids []string) {
+	checks := make([]driftCheck, 0, len(ids))
+	live := make(map[string]map[string]bool, len(m.hostOrder))
+	m.mu.RLock()
+	for _, proj := range m.projects {
+		if live[proj.Host] == nil {
+			live[proj.Host] = make(map[string]bool)
+		}
+		for _, ctr := range proj.Containers {
+			live[proj.Host][ctr.ID] = true
+		}
+	}
+	for _, id := range ids {
+		proj, ok := m.projects[id]
+		if !ok {
+			continue
+		}
+		check := driftCheck{id: id, host: proj.Host}
+		if proj.Source == model.SourceCompose && len(proj.Containers) > 0 {
+			check.config = m.scanner.ComposeModel(id)
+			check.containers = proj.Containers
+		}
+		checks = append(checks, check)
+	}
+	m.mu.RUnlock()
+
+	for name, h := range m.hosts {
+		h.runConfigs.retain(live[name])
+	}
+
+	drifts := make(map[string]*model.ProjectDrift, len(checks))
+	tagsByHost := make(map[string]map[string]string)
+
+	for _, check := range checks {
+		if check.config == nil {
+			drifts[check.id] = nil
+			continue
+		}
+
+		h, err := m.host(check.host)
+		if err != nil {
+			continue
+		}
+
+		tags, ok := tagsByHost[check.host]
+		if !ok {
+			tags = normalizedTags(ctx, h.client)
+			tagsByHost[check.host] = tags
+		}
+
+		drift, err := projectDrift(ctx, h, check, tags)
+		if err != nil {
+			slog.Default().Debug("checking drift",
+				"project", check.id,
+				"error", err,
+			)
+			continue
+		}
+		drifts[check.id] = drift
+	}
+
+	m.mu.Lock()
+	for id, drift := range drifts {
+		if proj, ok := m.projects[id]; ok {
+			proj.Drift = drift
+		}
+	}
+	m.mu.Unlock()
+}
+
+// determineDriftStatus summarizes the services found to differ.
+func determineDriftStatus(services []model.ServiceDrift) model.DriftStatus {
+	if len(services) > 0 {
+		return model.DriftDetected
+	}
+	return model.DriftInSync
+}
+
+func projectDrift(
+	ctx context.Context,
+	h *host,
+	check driftCheck,
+	tags map[string]string,
+) (*model.ProjectDrift, error) {
+	services := make([]model.ServiceDrift, 0)
+	for _, ctr := range check.containers {
+		if ctr.ServiceName == "" || ctr.Labels[oneOffLabel] == "True" {
+			continue
+		}
+
+		differences, err := containerDrift(ctx, h, check.config, ctr, tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(differences) > 0 {
+			services = append(services, model.ServiceDrift{
+				Service:     ctr.ServiceName,
+				Container:   ctr.Name,
+				Differences: differences,
+			})
+		}
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Service != services[j].Service {
+			return services[i].Service < services[j].Service
+		}
+		return services[i].Container < services[j].Container
+	})
+
+	return &model.ProjectDrift{
+		Status:    determineDriftStatus(services),
+		Services:  services,
+		CheckedAt: time.Now(),
+	}, nil
+}
+
+// containerDrift lists how a container differs from its service. A
+// container whose config-hash label matches the service is only checked
+// for a newer image under the same tag; otherwise it is inspected and
+// compared setting by setting. A differing hash alone is not reported, as
+// compose versions hash the same service differently.
+func containerDrift(
+	ctx context.Context,
+	h *host,
+	config *types.Project,
+	ctr model.Container,
+	tags map[string]string,
+) ([]model.DriftDifference, error) {
+	svc, ok := config.Services[ctr.ServiceName]
+	if !ok {
+		if _, disabled := config.DisabledServices[ctr.ServiceName]; disabled {
+			return nil, nil
+		}
+		return []model.DriftDifference{{
+			Field:    "service",
+			Expected: "not defined",
+			Running:  ctr.ServiceName,
+		}}, nil
+	}
+
+	image := svc.Image
+	if image == "" {
+		image = config.Name + "-" + svc.Name
+	}
+
+	hash, err := serviceHash(svc)
+	if err == nil && hash == ctr.Labels[configHashLabel] {
+		return imageDrift(image, ctr, tags), nil
+	}
+
+	run, err := h.runConfigs.get(ctx, h.client, ctr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := make([]model.DriftDifference, 0)
+	if normalizeImage(run.Image) != normalizeImage(image) {
+		differences = append(differences, model.DriftDifference{
+			Field:    "image",
+			Expected: image,
+			Running:  run.Image,
+		})
+	} else {
+		differences = append(differences, imageDrift(image, ctr, tags)...)
+	}
+
+	differences = append(differences,
+		mappingDrift("environment", environmentOf(svc), run.Env)...)
+	differences = append(differences, portDrift(svc, run)...)
+	differences = append(differences, mountDrift(config, svc, run)...)
+	differences = append(differences,
+		mappingDrift("labels", svc.Labels, run.Labels)...)
+
+	if svc.Command != nil && !slices.Equal(svc.Command, run.Command) {
+		differences = append(differences, model.DriftDifference{
+			Field:    "command",
+			Expected: strings.Join(svc.Command, " "),
+			Running:  strings.Join(run.Command, " "),
+		})
+	}
+	if svc.Entrypoint != nil && !slices.Equal(svc.Entrypoint, run.Entrypoint) {
+		differences = append(differences, model.DriftDifference{
+			Field:    "entrypoint",
+			Expected: strings.Join(svc.Entrypoint, " "),
+			Running:  strings.Join(run.Entrypoint, " "),
+		})
+	}
+
+	return differences, nil
+}
+
+// imageDrift reports a container running an older image than its tag
+// now points at, e.g. after a pull without an up.
+func imageDrift(
+	image string,
+	ctr model.Container,
+	tags map[string]string,
+) []model.DriftDifference {
+	current, ok := tags[normalizeImage(image)]
+	if !ok || ctr.ImageID == "" || current == ctr.ImageID {
+		return nil
+	}
+	return []model.DriftDifference{{
+		Field:    "image_digest",
+		Key:      image,
+		Expected: current,
+		Running:  ctr.ImageID,
+	}}
+}
+
+// mappingDrift compares the entries a service sets with the container's.
+// Entries the container has besides, such as those from its image, are
+// not drift.
+func mappingDrift(
+	field string,
+	expected, running map[string]string,
+) []model.DriftDifference {
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	differences := make([]model.DriftDifference, 0)
+	for _, key := range keys {
+		want := expected[key]
+		got, ok := running[key]
+		if ok && got == want {
+			continue
+		}
+		want, _ = scanner.MaskValue(key, want)
+		got, _ = scanner.MaskValue(key, got)
+		differences = append(differences, model.DriftDifference{
+			Field:    field,
+			Key:      key,
+			Expected: want,
+			Running:  got,
+		})
+	}
+	return differences
+}
+
+// environmentOf returns the variables a service sets, leaving out those
+// without a value, which compose passes through from its own environment.
+func environmentOf(svc types.ServiceConfig) map[string]string {
+	env := make(map[string]string, len(svc.Environment))
+	for name, value := range svc.Environment {
+		if value != nil {
+			env[name] = *value
+		}
+	}
+	return env
+}
+
+func portDrift(
+	svc types.ServiceConfig,
+	run *docker.RunConfig,
+) []model.DriftDifference {
+	expected := make([]string, 0, len(svc.Ports))
+	for _, port := range svc.Ports {
+		expected = append(expected, docker.FormatPort(
+			port.HostIP,
+			port.Published,
+			strconv.FormatUint(uint64(port.Target), 10),
+			port.Protocol,
+		))
+	}
+	sort.Strings(expected)
+
+	if slices.Equal(expected, run.Ports) {
+		return nil
+	}
+	return []model.DriftDifference{{
+		Field:    "ports",
+		Expected: strings.Join(expected, ", "),
+		Running:  strings.Join(run.Ports, ", "),
+	}}
+}
+
+// mountDrift compares the volumes a service declares with the container's
+// mounts at the same targets. Other mounts, such as anonymous volumes from
+// the image or secrets, are not compared.
+func mountDrift(
+	config *types.Project,
+	svc types.ServiceConfig,
+	run *docker.RunConfig,
+) []model.DriftDifference {
+	mounts := make(map[string]docker.RunMount, len(run.Mounts))
+	for _, mount := range run.Mounts {
+		mounts[mount.Target] = mount
+	}
+
+	differences := make([]model.DriftDifference, 0)
+	for _, volume := range svc.Volumes {
+		source := volume.Source
+		if volume.Type == types.VolumeTypeVolume {
+			declared, ok := config.Volumes[source]
+			if ok && declared.Name != "" {
+				source = declared.Name
+			}
+		}
+		want := docker.RunMount{
+			Type:     volume.Type,
+			Source:   source,
+			Target:   volume.Target,
+			ReadOnly: volume.ReadOnly,
+		}
+
+		got, ok := mounts[volume.Target]
+		if ok && got.Type == want.Type && got.ReadOnly == want.ReadOnly &&
+			(want.Source == "" || got.Source == want.Source) {
+			continue
+		}
+
+		difference := model.DriftDifference{
+			Field:    "mounts",
+			Key:      volume.Target,
+			Expected: describeMount(want),
+		}
+		if ok {
+			difference.Running = describeMount(got)
+		}
+		differences = append(differences, difference)
+	}
+	return differences
+}
+
+func describeMount(mount docker.RunMount) string {
+	description := mount.Type
+	if mount.Source != "" {
+		description += " " + mount.Source
+	}
+	if mount.ReadOnly {
+		description += " (read-only)"
+	}
+	return description
+}
+
+// serviceHash reproduces the config-hash label docker compose sets on the
+// containers it creates, leaving out the settings it ignores.
+func serviceHash(svc types.ServiceConfig) (string, error) {
+	svc.Build = nil
+	svc.PullPolicy = ""
+	svc.Scale = nil
+	if svc.Deploy != nil {
+		deploy := *svc.Deploy
+		replicas := 1
+		deploy.Replicas = &replicas
+		svc.Deploy = &deploy
+	}
+	svc.DependsOn = nil
+	svc.Profiles = nil
+
+	data, err := json.Marshal(svc)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalizedTags maps the fully qualified form of every image tag on a
+// host to its image ID, or is empty if the images cannot be listed.
+func normalizedTags(
+	ctx context.Context,
+	client *docker.Client,
+) map[string]string {
+	tagged, err := client.TaggedImages(ctx)
+	if err != nil {
+		slog.Default().Debug("listing images for drift", "error", err)
+		return map[string]string{}
+	}
+
+	tags := make(map[string]string, len(tagged))
+	for tag, id := range tagged {
+		tags[normalizeImage(tag)] = id
+	}
+	return tags
+}
+
+// normalizeImage expands an image reference so nginx and
+// docker.io/library/nginx:latest compare equal.
+func normalizeImage(image string) string {
+	ref, err := reference.ParseDockerRef(image)
+	if err != nil {
+		return image
+	}
+	return ref.String()
+}
@@ -0,0 +1,74 @@
+/*
+AngelaMos | 2026
+env.go
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ProjectEnv reports the variables a project's compose files reference,
+// where each value comes from and which are left unset, along with the
+// env files it is interpolated with and the ones it could choose from.
+func (m *Manager) ProjectEnv(
+	ctx context.Context,
+	id string,
+) (*model.ProjectEnv, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	files := proj.ComposeFiles
+	envFiles := envFilePaths(proj)
+	dir := proj.Path
+	m.mu.RUnlock()
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf(
+			"%w: %s has no compose files",
+			ErrUnsupported,
+			proj.Name,
+		)
+	}
+
+	variables, used, err := scanner.InspectEnv(ctx, files, envFiles)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting environment of %s: %w", proj.Name, err)
+	}
+
+	env := &model.ProjectEnv{
+		EnvFiles:          make([]string, len(used)),
+		AvailableEnvFiles: scanner.DetectEnvFiles(dir),
+		Variables:         variables,
+		Unset:             []string{},
+	}
+	for i, file := range used {
+		env.EnvFiles[i] = filepath.Base(file)
+	}
+	for _, variable := range variables {
+		if variable.Source == model.EnvSourceUnset {
+			env.Unset = append(env.Unset, variable.Name)
+		}
+	}
+
+	return env, nil
+}
+
+// envFilePaths resolves a project's chosen env files against its
+// directory.
+func envFilePaths(proj *model.Project) []string {
+	paths := make([]string, len(proj.EnvFiles))
+	for i, name := range proj.EnvFiles {
+		paths[i] = filepath.Join(proj.Path, name)
+	}
+	return paths
+}
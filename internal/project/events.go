@@ -0,0 +1,342 @@
+/*
+AngelaMos | 2026
+events.go
+*/
+
+package project
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+const (
+	eventDebounce      = 250 * time.Millisecond
+	eventMaxWait       = 2 * time.Second
+	eventRetryMin      = time.Second
+	eventRetryMax      = 30 * time.Second
+	eventPatchDeadline = 10 * time.Second
+)
+
+// OnProjectChange registers a callback invoked with a snapshot of a project
+// whenever its containers or status change. Must be set before WatchEvents.
+func (m *Manager) OnProjectChange(fn func(*model.Project)) {
+	m.mu.Lock()
+	m.onChange = fn
+	m.mu.Unlock()
+}
+
+// OnProjectRemove registers a callback invoked with the ID of a project
+// that no longer exists, such as an external project whose containers are
+// gone. Must be set before WatchEvents.
+func (m *Manager) OnProjectRemove(fn func(string)) {
+	m.mu.Lock()
+	m.onRemove = fn
+	m.mu.Unlock()
+}
+
+// WatchEvents subscribes to the event stream of every Docker host and
+// patches project state as containers change, instead of waiting for the
+// next full Refresh. Blocks until the context is cancelled.
+func (m *Manager) WatchEvents(ctx context.Context) {
+	done := make(chan struct{})
+
+	for _, name := range m.hostOrder {
+		go func(name string) {
+			defer func() { done <- struct{}{} }()
+			m.watchHostEvents(ctx, name)
+		}(name)
+	}
+
+	for range m.hostOrder {
+		<-done
+	}
+}
+
+// watchHostEvents consumes one host's event stream, resubscribing with
+// backoff when it breaks. Events are coalesced per compose project so a
+// `compose down` emitting dozens of events causes a single patch. A steady
+// stream, such as a restart loop, is still flushed every eventMaxWait.
+func (m *Manager) watchHostEvents(ctx context.Context, hostName string) {
+	logger := slog.Default().With("host", hostName)
+	client := m.hosts[hostName].client
+	retry := eventRetryMin
+	resubscribed := false
+
+	for {
+		eventCh, errCh := client.Events(ctx)
+
+		if resubscribed {
+			// Events may have been missed while disconnected.
+			if err := m.Refresh(ctx); err != nil {
+				logger.Warn("reconciling after event stream loss", "error", err)
+			}
+		}
+
+		pending := make(map[string]bool)
+		var firstPending time.Time
+		flush := time.NewTimer(eventDebounce)
+		flush.Stop()
+
+	consume:
+		for {
+			select {
+			case <-ctx.Done():
+				flush.Stop()
+				return
+
+			case ev, ok := <-eventCh:
+				if !ok {
+					break consume
+				}
+				if !ev.AffectsState() {
+					continue
+				}
+
+				composeName := m.composeNameForEvent(ev)
+				if composeName == "" {
+					continue
+				}
+
+				retry = eventRetryMin
+				if len(pending) == 0 {
+					firstPending = time.Now()
+				}
+				pending[composeName] = true
+				flush.Reset(min(
+					eventDebounce,
+					eventMaxWait-time.Since(firstPending),
+				))
+
+			case <-flush.C:
+				for composeName := range pending {
+					m.patchComposeProject(ctx, hostName, composeName)
+				}
+				pending = make(map[string]bool)
+			}
+		}
+
+		flush.Stop()
+		if err := <-errCh; err != nil {
+			logger.Warn("docker event stream interrupted", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+
+		retry = min(retry*2, eventRetryMax)
+		resubscribed = true
+	}
+}
+
+// composeNameForEvent resolves the compose project an event belongs to.
+// Network and volume events only reference a container, which is looked up
+// among known projects.
+func (m *Manager) composeNameForEvent(ev docker.Event) string {
+	if name := ev.ComposeProject(); name != "" {
+		return name
+	}
+	// Container events carry the container's labels, so a missing compose
+	// label means a standalone container.
+	if ev.IsContainer() {
+		return standaloneGroup
+	}
+
+	containerID := ev.ContainerID()
+	if containerID == "" {
+		return ""
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for id, proj := range m.projects {
+		for _, ctr := range proj.Containers {
+			if strings.HasPrefix(ctr.ID, containerID) {
+				return m.composeNames[id]
+			}
+		}
+	}
+
+	return ""
+}
+
+// patchComposeProject re-lists the containers of a single compose project on
+// a host and updates every project mapped to it.
+func (m *Manager) patchComposeProject(
+	ctx context.Context,
+	hostName, composeName string,
+) {
+	if composeName == standaloneGroup {
+		m.patchStandalone(ctx, hostName)
+		return
+	}
+
+	h, err := m.host(hostName)
+	if err != nil {
+		return
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, eventPatchDeadline)
+	defer cancel()
+
+	containers, err := h.client.ListContainers(listCtx, composeName)
+	if err != nil {
+		slog.Default().Warn("patching project from event",
+			"host", hostName,
+			"compose_project", composeName,
+			"error", err,
+		)
+		return
+	}
+
+	m.mu.Lock()
+	patched := make([]string, 0, 1)
+	removed := make([]string, 0)
+	matched := false
+	for id, proj := range m.projects {
+		if m.composeNames[id] != composeName {
+			continue
+		}
+		// A project lives on one host. External projects are tied to
+		// theirs; a scanned project only moves to a host this event
+		// brought it up on if it is not running on its own and no other
+		// project there has the name.
+		if proj.Host != hostName && (proj.Source == model.SourceExternal ||
+			len(containers) == 0 ||
+			len(proj.Containers) > 0 ||
+			m.claimedLocked(hostName, composeName)) {
+			continue
+		}
+		matched = true
+		// External projects only exist through their containers.
+		if proj.Source == model.SourceExternal && len(containers) == 0 {
+			delete(m.projects, id)
+			delete(m.composeNames, id)
+			removed = append(removed, id)
+			continue
+		}
+
+		proj.Host = hostName
+		proj.Containers = containers
+		proj.Status = determineProjectStatus(containers)
+		m.applyProtection(proj)
+		proj.UpdatedAt = time.Now()
+
+		patched = append(patched, id)
+	}
+	m.mu.Unlock()
+
+	m.checkDrift(listCtx, patched)
+
+	m.mu.RLock()
+	changed := make([]model.Project, 0, len(patched))
+	for _, id := range patched {
+		if proj, ok := m.projects[id]; ok {
+			changed = append(changed, *proj)
+		}
+	}
+	onChange := m.onChange
+	m.mu.RUnlock()
+
+	if !matched && len(containers) > 0 {
+		m.addExternal(hostName, composeName, containers)
+	}
+	m.notifyRemoved(removed)
+
+	if onChange == nil {
+		return
+	}
+	for i := range changed {
+		onChange(&changed[i])
+	}
+}
+
+type projectState struct {
+	host       string
+	status     model.ProjectStatus
+	drift      model.DriftStatus
+	containers string
+}
+
+func projectStates(projects map[string]*model.Project) map[string]projectState {
+	states := make(map[string]projectState, len(projects))
+	for id, proj := range projects {
+		states[id] = stateOf(proj)
+	}
+	return states
+}
+
+func stateOf(proj *model.Project) projectState {
+	var containers strings.Builder
+	for _, ctr := range proj.Containers {
+		containers.WriteString(ctr.ID)
+		containers.WriteString(ctr.State)
+		containers.WriteString(ctr.Health)
+	}
+
+	state := projectState{
+		host:       proj.Host,
+		status:     proj.Status,
+		containers: containers.String(),
+	}
+	if proj.Drift != nil {
+		state.drift = proj.Drift.Status
+	}
+	return state
+}
+
+// notifyChanges reports projects whose state differs from a prior snapshot
+// and those in the snapshot that are gone.
+func (m *Manager) notifyChanges(
+	previous map[string]projectState,
+	current map[string]*model.Project,
+) {
+	m.mu.RLock()
+	onChange := m.onChange
+	changed := make([]model.Project, 0)
+	for id, proj := range current {
+		if prev, ok := previous[id]; ok && prev == stateOf(proj) {
+			continue
+		}
+		changed = append(changed, *proj)
+	}
+	m.mu.RUnlock()
+
+	removed := make([]string, 0)
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	m.notifyRemoved(removed)
+
+	if onChange == nil {
+		return
+	}
+	for i := range changed {
+		onChange(&changed[i])
+	}
+}
+
+// notifyRemoved reports projects that no longer exist.
+func (m *Manager) notifyRemoved(ids []string) {
+	m.mu.RLock()
+	onRemove := m.onRemove
+	m.mu.RUnlock()
+
+	if onRemove == nil {
+		return
+	}
+	for _, id := range ids {
+		onRemove(id)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+AngelaMos | 2026
+external.go
+*/
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+// Labels compose sets on every container it creates.
+const (
+	labelWorkingDir  = "com.docker.compose.project.working_dir"
+	labelConfigFiles = "com.docker.compose.project.config_files"
+)
+
+// ErrReadOnly is returned for compose operations on a project whose
+// compose files cannot be read.
+var ErrReadOnly = errors.New("project is read-only")
+
+// newExternalProject rebuilds a running compose project from its
+// containers' labels. Its ID is derived from the compose file path like a
+// scanned project's, so it keeps its ID, preferences and rules if the
+// path is added to the scanner later.
+func newExternalProject(
+	hostName, composeName string,
+	containers []model.Container,
+) *model.Project {
+	var workingDir string
+	var configFiles []string
+	services := make([]string, 0)
+
+	for _, ctr := range containers {
+		if workingDir == "" {
+			workingDir = ctr.Labels[labelWorkingDir]
+		}
+		if len(configFiles) == 0 && ctr.Labels[labelConfigFiles] != "" {
+			configFiles = strings.Split(ctr.Labels[labelConfigFiles], ",")
+		}
+		if ctr.ServiceName != "" && !slices.Contains(services, ctr.ServiceName) {
+			services = append(services, ctr.ServiceName)
+		}
+	}
+	sort.Strings(services)
+
+	proj := &model.Project{
+		Name:        composeName,
+		Source:      model.SourceExternal,
+		Host:        hostName,
+		Path:        workingDir,
+		Environment: model.EnvUnknown,
+		Status:      determineProjectStatus(containers),
+		Containers:  containers,
+		Services:    services,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if len(configFiles) == 0 {
+		proj.ID = scanner.ProjectID("external/" + hostName + "/" + composeName)
+		proj.ReadOnly = true
+		return proj
+	}
+
+	files := make([]string, len(configFiles))
+	for i, path := range configFiles {
+		if !filepath.IsAbs(path) && workingDir != "" {
+			path = filepath.Join(workingDir, path)
+		}
+		files[i] = path
+		if !readable(path) {
+			proj.ReadOnly = true
+		}
+	}
+
+	path := files[0]
+	proj.ID = scanner.ProjectID(path)
+	proj.ComposeFile = filepath.Base(path)
+	proj.ComposeFilePath = path
+	proj.ComposeFiles = files
+	proj.Environment = scanner.DetectEnvironment(path)
+
+	return proj
+}
+
+func readable(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
+
+// addExternalLocked creates projects for running compose projects that no
+// scanned project claims. Callers hold the manager lock.
+func (m *Manager) addExternalLocked(
+	projects map[string]*model.Project,
+	composeNames map[string]string,
+	containersByHost map[string]map[string][]model.Container,
+	prefs map[string]*store.ProjectPreference,
+	rules map[string]*model.ProtectionRule,
+) {
+	claimed := make(map[string]bool, len(composeNames))
+	for id, name := range composeNames {
+		if proj, ok := projects[id]; ok {
+			claimed[claimKey(proj.Host, name)] = true
+		}
+	}
+
+	for _, hostName := range m.hostOrder {
+		grouped := containersByHost[hostName]
+
+		names := make([]string, 0, len(grouped))
+		for name := range grouped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, composeName := range names {
+			if composeName == standaloneGroup || claimed[claimKey(hostName, composeName)] {
+				continue
+			}
+
+			proj := newExternalProject(hostName, composeName, grouped[composeName])
+			if !assignExternalID(proj, projects) {
+				continue
+			}
+
+			m.adoptLocked(proj, prefs, rules)
+			projects[proj.ID] = proj
+			composeNames[proj.ID] = composeName
+			claimed[claimKey(hostName, composeName)] = true
+		}
+	}
+}
+
+// addExternal creates a project for a compose project that came up
+// outside the scan paths since the last refresh.
+func (m *Manager) addExternal(
+	hostName, composeName string,
+	containers []model.Container,
+) {
+	var prefs map[string]*store.ProjectPreference
+	if m.store != nil {
+		prefs, _ = m.store.GetAllPreferences()
+	}
+	rules := m.protectionRules()
+
+	m.mu.Lock()
+	if m.claimedLocked(hostName, composeName) {
+		m.mu.Unlock()
+		return
+	}
+
+	proj := newExternalProject(hostName, composeName, containers)
+	if !assignExternalID(proj, m.projects) {
+		m.mu.Unlock()
+		return
+	}
+
+	m.adoptLocked(proj, prefs, rules)
+	m.projects[proj.ID] = proj
+	m.composeNames[proj.ID] = composeName
+	snapshot := *proj
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if onChange != nil {
+		onChange(&snapshot)
+	}
+}
+
+// claimKey identifies a compose project on a host. Compose names are only
+// unique per host, so the same name may be claimed once on each.
+func claimKey(hostName, composeName string) string {
+	return hostName + "/" + composeName
+}
+
+// claimedLocked reports whether a project already stands for a compose
+// project on a host. Callers hold the manager lock.
+func (m *Manager) claimedLocked(hostName, composeName string) bool {
+	for id, name := range m.composeNames {
+		if name != composeName {
+			continue
+		}
+		if proj, ok := m.projects[id]; ok && proj.Host == hostName {
+			return true
+		}
+	}
+	return false
+}
+
+// assignExternalID makes an external project's ID unique among projects.
+// When another project already has the ID derived from its compose file
+// path, such as the same checkout running on another host, the host is
+// included in the ID. It reports false if that ID is taken as well.
+func assignExternalID(
+	proj *model.Project,
+	projects map[string]*model.Project,
+) bool {
+	if _, exists := projects[proj.ID]; !exists {
+		return true
+	}
+	if proj.ComposeFilePath == "" {
+		return false
+	}
+
+	proj.ID = scanner.ProjectID("external/" + proj.Host + "/" + proj.ComposeFilePath)
+	_, exists := projects[proj.ID]
+	return !exists
+}
+
+// adoptLocked carries sticky protection over from the project a rebuilt
+// project replaces, applies preferences and rules, then protection.
+// Callers hold the manager lock.
+func (m *Manager) adoptLocked(
+	proj *model.Project,
+	prefs map[string]*store.ProjectPreference,
+	rules map[string]*model.ProtectionRule,
+) {
+	if existing, ok := m.projects[proj.ID]; ok {
+		proj.Protected = existing.Protected
+		proj.ProtectionReason = existing.ProtectionReason
+		proj.CreatedAt = existing.CreatedAt
+	}
+	if pref, ok := prefs[proj.ID]; ok {
+		proj.DisplayName = pref.DisplayName
+		proj.Hidden = pref.Hidden
+	}
+	proj.ProtectionRule = rules[proj.ID]
+	m.applyProtection(proj)
+}
+
+// checkWritable refuses compose operations on read-only projects.
+func checkWritable(proj *model.Project) error {
+	if proj.ReadOnly {
+		return fmt.Errorf(
+			"%w: %s - compose files %q are not available",
+			ErrReadOnly,
+			proj.Name,
+			proj.ComposeFiles,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+AngelaMos | 2026
+graph.go
+*/
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ProjectGraph returns the dependency graph of a scanned project's
+// services, each with the current state of its containers.
+func (m *Manager) ProjectGraph(id string) (*model.ServiceGraph, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	config := m.scanner.ComposeModel(id)
+	if config == nil {
+		return nil, fmt.Errorf(
+			"%w: %s has no scanned compose model",
+			ErrUnsupported,
+			proj.Name,
+		)
+	}
+
+	m.mu.RLock()
+	byService := make(map[string][]model.Container)
+	for _, ctr := range proj.Containers {
+		byService[ctr.ServiceName] = append(byService[ctr.ServiceName], ctr)
+	}
+	m.mu.RUnlock()
+
+	graph := &model.ServiceGraph{
+		Nodes:    make([]model.GraphNode, 0, len(config.Services)),
+		Edges:    scanner.DependencyEdges(config),
+		Warnings: scanner.DependencyWarnings(config),
+	}
+	for _, name := range config.ServiceNames() {
+		containers := byService[name]
+		graph.Nodes = append(graph.Nodes, model.GraphNode{
+			Service:    name,
+			Status:     determineProjectStatus(containers),
+			Health:     serviceHealth(containers),
+			Containers: len(containers),
+		})
+	}
+
+	return graph, nil
+}
+
+// serviceHealth summarizes the health of a service's containers by the
+// worst of them; it is empty when none has a health check.
+func serviceHealth(containers []model.Container) string {
+	health := ""
+	for _, ctr := range containers {
+		switch ctr.Health {
+		case "unhealthy":
+			return ctr.Health
+		case "starting":
+			health = ctr.Health
+		case "healthy":
+			if health == "" {
+				health = ctr.Health
+			}
+		}
+	}
+	return health
+}
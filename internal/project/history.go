@@ -0,0 +1,298 @@
+/*
+AngelaMos | 2026
+history.go
+*/
+
+package project
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+const (
+	historyMaintenanceInterval = time.Minute
+	historyTargetPoints        = 300
+)
+
+// ErrHistoryUnavailable is returned when stats history is disabled or the
+// store could not be opened.
+var ErrHistoryUnavailable = errors.New("stats history is not enabled")
+
+type HistoryConfig struct {
+	SampleInterval  time.Duration
+	RawRetention    time.Duration
+	MinuteRetention time.Duration
+	HourRetention   time.Duration
+}
+
+func (c HistoryConfig) normalized() HistoryConfig {
+	if c.SampleInterval <= 0 {
+		c.SampleInterval = 10 * time.Second
+	}
+	// Rollups re-read the previous bucket, so each tier must outlive the
+	// window the next tier aggregates from it.
+	c.RawRetention = max(c.RawRetention, 5*time.Minute)
+	c.MinuteRetention = max(c.MinuteRetention, 3*time.Hour)
+	c.HourRetention = max(c.HourRetention, 24*time.Hour)
+	return c
+}
+
+// RecordStatsHistory samples stats of running projects into the store and
+// maintains raw -> 1m -> 1h rollups and retention until ctx is cancelled.
+// Sampling runs independently of WebSocket clients so history has no gaps
+// when nobody is watching.
+func (m *Manager) RecordStatsHistory(ctx context.Context, cfg HistoryConfig) {
+	if m.store == nil {
+		return
+	}
+
+	cfg = cfg.normalized()
+
+	m.mu.Lock()
+	m.history = &cfg
+	m.mu.Unlock()
+
+	logger := slog.Default()
+
+	sampleTicker := time.NewTicker(cfg.SampleInterval)
+	defer sampleTicker.Stop()
+
+	maintenanceTicker := time.NewTicker(historyMaintenanceInterval)
+	defer maintenanceTicker.Stop()
+
+	// The first pass catches up on anything left unrolled by a restart.
+	catchUp := true
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-sampleTicker.C:
+			if err := m.sampleStats(ctx); err != nil {
+				logger.Warn("failed to record stats history", "error", err)
+			}
+
+		case <-maintenanceTicker.C:
+			if err := m.maintainHistory(cfg, time.Now(), catchUp); err != nil {
+				logger.Warn("failed to maintain stats history", "error", err)
+				continue
+			}
+			catchUp = false
+		}
+	}
+}
+
+func (m *Manager) sampleStats(ctx context.Context) error {
+	samples := make([]store.StatsSample, 0)
+
+	for _, proj := range m.ListProjects() {
+		if proj.Status != model.StatusRunning &&
+			proj.Status != model.StatusPartial {
+			continue
+		}
+
+		stats, err := m.GetProjectStats(ctx, proj.ID)
+		if err != nil {
+			continue
+		}
+
+		for _, ctr := range proj.Containers {
+			ctrStats, ok := stats[ctr.ID]
+			if !ok {
+				continue
+			}
+
+			samples = append(samples, store.StatsSample{
+				Timestamp:     ctrStats.Timestamp,
+				Host:          ctr.Host,
+				ProjectID:     proj.ID,
+				ContainerID:   ctr.ID,
+				ContainerName: ctr.Name,
+				ServiceName:   ctr.ServiceName,
+				CPUPercent:    ctrStats.CPUPercent,
+				MemoryUsage:   ctrStats.MemoryUsage,
+				MemoryLimit:   ctrStats.MemoryLimit,
+				NetworkRx:     ctrStats.NetworkRx,
+				NetworkTx:     ctrStats.NetworkTx,
+				BlockRead:     ctrStats.BlockRead,
+				BlockWrite:    ctrStats.BlockWrite,
+				PIDs:          ctrStats.PIDs,
+			})
+		}
+	}
+
+	return m.store.InsertStatsSamples(samples)
+}
+
+// maintainHistory rolls completed buckets up one tier and enforces
+// retention. Normally only the last two buckets are re-aggregated.
+func (m *Manager) maintainHistory(
+	cfg HistoryConfig,
+	now time.Time,
+	catchUp bool,
+) error {
+	minuteEnd := now.Truncate(time.Minute)
+	minuteStart := minuteEnd.Add(-2 * time.Minute)
+	hourEnd := now.Truncate(time.Hour)
+	hourStart := hourEnd.Add(-2 * time.Hour)
+
+	if catchUp {
+		minuteStart = now.Add(-cfg.RawRetention)
+		hourStart = now.Add(-cfg.MinuteRetention)
+	}
+
+	if err := m.store.RollupStats(
+		store.ResolutionRaw,
+		store.ResolutionMinute,
+		minuteStart,
+		minuteEnd,
+	); err != nil {
+		return err
+	}
+
+	if err := m.store.RollupStats(
+		store.ResolutionMinute,
+		store.ResolutionHour,
+		hourStart,
+		hourEnd,
+	); err != nil {
+		return err
+	}
+
+	retention := map[int]time.Duration{
+		store.ResolutionRaw:    cfg.RawRetention,
+		store.ResolutionMinute: cfg.MinuteRetention,
+		store.ResolutionHour:   cfg.HourRetention,
+	}
+	for resolution, keep := range retention {
+		if err := m.store.PruneStats(resolution, now.Add(-keep)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetStatsHistory returns per-container and project-wide stats series for a
+// time range. The finest stored resolution that still covers the range is
+// used; a zero step picks one yielding roughly historyTargetPoints points.
+func (m *Manager) GetStatsHistory(
+	id string,
+	from, to time.Time,
+	step time.Duration,
+) (*model.StatsHistory, error) {
+	if _, err := m.GetProject(id); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	cfg := m.history
+	m.mu.RUnlock()
+
+	if cfg == nil || m.store == nil {
+		return nil, ErrHistoryUnavailable
+	}
+
+	if step <= 0 {
+		step = to.Sub(from) / historyTargetPoints
+	}
+
+	age := time.Since(from)
+	resolution := store.ResolutionHour
+	switch {
+	case step < time.Minute && age <= cfg.RawRetention:
+		resolution = store.ResolutionRaw
+	case step < time.Hour && age <= cfg.MinuteRetention:
+		resolution = store.ResolutionMinute
+	}
+
+	stepSeconds := max(
+		int(step.Seconds()),
+		resolution,
+		int(cfg.SampleInterval.Seconds()),
+		1,
+	)
+
+	samples, err := m.store.QueryStats(id, resolution, from, to, stepSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &model.StatsHistory{
+		ProjectID:  id,
+		From:       from,
+		To:         to,
+		Step:       stepSeconds,
+		Containers: make([]model.StatsSeries, 0),
+		Project:    make([]model.StatsPoint, 0),
+	}
+
+	series := make(map[string]*model.StatsSeries)
+	totals := make(map[int64]*model.StatsPoint)
+
+	for _, sample := range samples {
+		point := model.StatsPoint{
+			Timestamp:   sample.Timestamp,
+			CPUPercent:  sample.CPUPercent,
+			MemoryUsage: sample.MemoryUsage,
+			MemoryLimit: sample.MemoryLimit,
+			NetworkRx:   sample.NetworkRx,
+			NetworkTx:   sample.NetworkTx,
+			BlockRead:   sample.BlockRead,
+			BlockWrite:  sample.BlockWrite,
+			PIDs:        sample.PIDs,
+		}
+
+		s, ok := series[sample.ContainerID]
+		if !ok {
+			s = &model.StatsSeries{
+				ContainerID:   sample.ContainerID,
+				ContainerName: sample.ContainerName,
+				ServiceName:   sample.ServiceName,
+				Host:          sample.Host,
+				Points:        make([]model.StatsPoint, 0),
+			}
+			series[sample.ContainerID] = s
+		}
+		s.Points = append(s.Points, point)
+
+		total, ok := totals[sample.Timestamp.Unix()]
+		if !ok {
+			total = &model.StatsPoint{Timestamp: sample.Timestamp}
+			totals[sample.Timestamp.Unix()] = total
+		}
+		total.CPUPercent += point.CPUPercent
+		total.MemoryUsage += point.MemoryUsage
+		total.MemoryLimit += point.MemoryLimit
+		total.NetworkRx += point.NetworkRx
+		total.NetworkTx += point.NetworkTx
+		total.BlockRead += point.BlockRead
+		total.BlockWrite += point.BlockWrite
+		total.PIDs += point.PIDs
+	}
+
+	for _, s := range series {
+		history.Containers = append(history.Containers, *s)
+	}
+	sort.Slice(history.Containers, func(i, j int) bool {
+		return history.Containers[i].ContainerName <
+			history.Containers[j].ContainerName
+	})
+
+	for _, total := range totals {
+		history.Project = append(history.Project, *total)
+	}
+	sort.Slice(history.Project, func(i, j int) bool {
+		return history.Project[i].Timestamp.Before(history.Project[j].Timestamp)
+	})
+
+	return history, nil
+}
@@ -0,0 +1,155 @@
+/*
+AngelaMos | 2026
+hosts.go
+*/
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+// ErrUnknownHost is returned when a request names a Docker host that is
+// not configured.
+var ErrUnknownHost = errors.New("unknown docker host")
+
+type host struct {
+	client         *docker.Client
+	statsCollector *docker.StatsCollector
+	runConfigs     *runConfigCache
+}
+
+// ListHosts returns every configured Docker host with its reachability.
+func (m *Manager) ListHosts(ctx context.Context) []model.HostInfo {
+	hosts := make([]model.HostInfo, 0, len(m.hostOrder))
+
+	for i, name := range m.hostOrder {
+		h := m.hosts[name]
+		info := model.HostInfo{
+			Name:     name,
+			Endpoint: h.client.Endpoint().Host,
+			Default:  i == 0,
+		}
+
+		if err := h.client.Ping(ctx); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Available = true
+		}
+
+		hosts = append(hosts, info)
+	}
+
+	return hosts
+}
+
+// DefaultHost returns the name of the default Docker host.
+func (m *Manager) DefaultHost() string {
+	if len(m.hostOrder) == 0 {
+		return ""
+	}
+	return m.hostOrder[0]
+}
+
+// host resolves a host by name. An empty name selects the default host.
+func (m *Manager) host(name string) (*host, error) {
+	if name == "" {
+		name = m.DefaultHost()
+	}
+
+	h, ok := m.hosts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHost, name)
+	}
+	return h, nil
+}
+
+// hostForContainer finds the host a known container lives on, falling back
+// to the default host for containers not attached to any project.
+func (m *Manager) hostForContainer(containerID string) *host {
+	if ctr, ok := m.FindContainer(containerID); ok {
+		if h, ok := m.hosts[ctr.Host]; ok {
+			return h
+		}
+	}
+
+	return m.hosts[m.DefaultHost()]
+}
+
+// FindContainer looks up a container known to any project by full or
+// short ID.
+func (m *Manager) FindContainer(containerID string) (model.Container, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if containerID == "" {
+		return model.Container{}, false
+	}
+
+	for _, proj := range m.projects {
+		for _, ctr := range proj.Containers {
+			if strings.HasPrefix(ctr.ID, containerID) {
+				return ctr, true
+			}
+		}
+	}
+
+	return model.Container{}, false
+}
+
+// containersByHost lists compose containers on every host.
+// Unreachable hosts are skipped so one offline box does not hide the rest;
+// an error is only returned when no host could be queried.
+func (m *Manager) containersByHost(
+	ctx context.Context,
+) (map[string]map[string][]model.Container, error) {
+	result := make(map[string]map[string][]model.Container, len(m.hosts))
+
+	var lastErr error
+	for _, name := range m.hostOrder {
+		grouped, err := m.hosts[name].client.GetContainersByComposeProject(ctx)
+		if err != nil {
+			lastErr = fmt.Errorf("host %s: %w", name, err)
+			continue
+		}
+		result[name] = grouped
+	}
+
+	if len(result) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return result, nil
+}
+
+// assignContainers attaches a project to the first host running its compose
+// project. Projects running nowhere keep their previous host, or the default.
+func (m *Manager) assignContainers(
+	proj *model.Project,
+	projectName string,
+	containersByHost map[string]map[string][]model.Container,
+) {
+	for _, name := range m.hostOrder {
+		containers, ok := containersByHost[name][projectName]
+		if !ok {
+			continue
+		}
+
+		proj.Host = name
+		proj.Containers = containers
+		proj.Status = determineProjectStatus(containers)
+		return
+	}
+
+	if _, ok := m.hosts[proj.Host]; !ok {
+		proj.Host = m.DefaultHost()
+	}
+	proj.Containers = []model.Container{}
+	proj.Status = model.StatusStopped
+}
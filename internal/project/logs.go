@@ -0,0 +1,116 @@
+/*
+AngelaMos | 2026
+logs.go
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+)
+
+type ProjectLogOptions struct {
+	Since    string
+	Until    string
+	Tail     int
+	Services []string
+}
+
+type ProjectLogLine struct {
+	ContainerID   string `json:"container_id"`
+	ContainerName string `json:"container_name"`
+	ServiceName   string `json:"service_name"`
+	docker.LogLine
+}
+
+// GetProjectLogs fetches logs from every container in a project and merges
+// them into one chronologically ordered stream. Tail limits the merged
+// result, not each container, so a chatty service cannot hide the others.
+func (m *Manager) GetProjectLogs(
+	ctx context.Context,
+	id string,
+	opts ProjectLogOptions,
+) ([]ProjectLogLine, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	logOpts := docker.LogOptions{
+		Since:      opts.Since,
+		Until:      opts.Until,
+		Timestamps: true,
+		Tail:       "all",
+	}
+	if opts.Tail > 0 {
+		// No container can contribute more than the merged tail.
+		logOpts.Tail = strconv.Itoa(opts.Tail)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		merged  []ProjectLogLine
+		lastErr error
+		fetched int
+	)
+
+	for _, ctr := range proj.Containers {
+		if len(opts.Services) > 0 &&
+			!slices.Contains(opts.Services, ctr.ServiceName) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lines, err := m.GetContainerLogLines(ctx, ctr.ID, logOpts)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				lastErr = fmt.Errorf("container %s: %w", ctr.Name, err)
+				return
+			}
+			fetched++
+
+			for _, line := range lines {
+				merged = append(merged, ProjectLogLine{
+					ContainerID:   ctr.ID,
+					ContainerName: ctr.Name,
+					ServiceName:   ctr.ServiceName,
+					LogLine:       line,
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if fetched == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+
+	if opts.Tail > 0 && len(merged) > opts.Tail {
+		merged = merged[len(merged)-opts.Tail:]
+	}
+
+	if merged == nil {
+		merged = []ProjectLogLine{}
+	}
+
+	return merged, nil
+}
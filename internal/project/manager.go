@@ -7,7 +7,10 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,58 +20,102 @@ import (
 	"github.com/carterperez-dev/holophyly/internal/store"
 )
 
+var (
+	// ErrProjectNotFound is returned when a project ID is not known.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrProjectProtected is returned when an operation is refused because
+	// the project is protected.
+	ErrProjectProtected = errors.New("project is protected")
+)
+
 type Manager struct {
-	docker         *docker.Client
-	scanner        *scanner.Scanner
-	statsCollector *docker.StatsCollector
-	store          *store.Store
-	projects       map[string]*model.Project
-	protection     *ProtectionConfig
-	mu             sync.RWMutex
+	hosts     map[string]*host
+	hostOrder []string
+	scanner   *scanner.Scanner
+	store     *store.Store
+	projects  map[string]*model.Project
+	// composeNames maps project IDs to their resolved compose project name,
+	// used to match containers and events back to projects.
+	composeNames map[string]string
+	protection   *ProtectionConfig
+	onChange     func(*model.Project)
+	onRemove     func(string)
+	history      *HistoryConfig
+	lastScan     *scanner.ScanResult
+	lastScanAt   time.Time
+	mu           sync.RWMutex
 }
 
 // NewManager creates a project manager that orchestrates docker and scanner.
+// The first docker client is the default host for projects that are not
+// running anywhere yet.
 func NewManager(
-	dockerClient *docker.Client,
+	dockerClients []*docker.Client,
 	fileScanner *scanner.Scanner,
 	protection *ProtectionConfig,
 	prefStore *store.Store,
 ) *Manager {
-	return &Manager{
-		docker:         dockerClient,
-		scanner:        fileScanner,
-		statsCollector: docker.NewStatsCollector(dockerClient),
-		store:          prefStore,
-		projects:       make(map[string]*model.Project),
-		protection:     protection,
+	m := &Manager{
+		hosts:        make(map[string]*host),
+		hostOrder:    make([]string, 0, len(dockerClients)),
+		scanner:      fileScanner,
+		store:        prefStore,
+		projects:     make(map[string]*model.Project),
+		composeNames: make(map[string]string),
+		protection:   protection,
+	}
+
+	for _, client := range dockerClients {
+		m.hosts[client.Name()] = &host{
+			client:         client,
+			statsCollector: docker.NewStatsCollector(client),
+			runConfigs:     newRunConfigCache(),
+		}
+		m.hostOrder = append(m.hostOrder, client.Name())
 	}
+
+	return m
 }
 
 // Refresh scans for compose files and updates project state with running containers.
 func (m *Manager) Refresh(ctx context.Context) error {
+	var prefs map[string]*store.ProjectPreference
+	if m.store != nil {
+		var err error
+		if prefs, err = m.store.GetAllPreferences(); err == nil {
+			m.scanner.SetAllOptions(projectOptions(prefs))
+		}
+	}
+
 	result, err := m.scanner.Scan(ctx)
 	if err != nil {
 		return fmt.Errorf("scanning for projects: %w", err)
 	}
+	slog.Default().Debug("scanned for projects",
+		"projects", len(result.Projects),
+		"duration", result.Duration,
+		"discover", result.Timings.Discover,
+		"parse", result.Timings.Parse,
+	)
 
-	containersByProject, err := m.docker.GetContainersByComposeProject(ctx)
+	containersByHost, err := m.containersByHost(ctx)
 	if err != nil {
 		return fmt.Errorf("getting containers: %w", err)
 	}
 
-	var prefs map[string]*store.ProjectPreference
-	if m.store != nil {
-		prefs, _ = m.store.GetAllPreferences()
-	}
+	rules := m.protectionRules()
 
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
+	// Scanned projects are cached pointers, so capture state before patching.
+	previous := projectStates(m.projects)
 	newProjects := make(map[string]*model.Project)
+	composeNames := make(map[string]string)
 
 	for _, proj := range result.Projects {
 		existing, exists := m.projects[proj.ID]
 		if exists {
+			proj.Host = existing.Host
 			proj.Protected = existing.Protected
 			proj.ProtectionReason = existing.ProtectionReason
 		}
@@ -79,15 +126,10 @@ func (m *Manager) Refresh(ctx context.Context) error {
 				proj.Hidden = pref.Hidden
 			}
 		}
+		proj.ProtectionRule = rules[proj.ID]
 
-		projectName := docker.GetComposeProjectName(proj.ComposeFilePath)
-		if containers, ok := containersByProject[projectName]; ok {
-			proj.Containers = containers
-			proj.Status = determineProjectStatus(containers)
-		} else {
-			proj.Status = model.StatusStopped
-			proj.Containers = []model.Container{}
-		}
+		composeNames[proj.ID] = proj.Name
+		m.assignContainers(proj, proj.Name, containersByHost)
 
 		m.applyProtection(proj)
 
@@ -95,7 +137,34 @@ func (m *Manager) Refresh(ctx context.Context) error {
 		newProjects[proj.ID] = proj
 	}
 
+	m.addExternalLocked(
+		newProjects,
+		composeNames,
+		containersByHost,
+		prefs,
+		rules,
+	)
+	m.addStandaloneLocked(
+		newProjects,
+		composeNames,
+		containersByHost,
+		prefs,
+		rules,
+	)
+
 	m.projects = newProjects
+	m.composeNames = composeNames
+	m.lastScan = result
+	m.lastScanAt = time.Now()
+	m.mu.Unlock()
+
+	scanned := make([]string, len(result.Projects))
+	for i, proj := range result.Projects {
+		scanned[i] = proj.ID
+	}
+	m.checkDrift(ctx, scanned)
+
+	m.notifyChanges(previous, newProjects)
 	return nil
 }
 
@@ -138,21 +207,65 @@ func (m *Manager) GetProject(id string) (*model.Project, error) {
 
 	proj, exists := m.projects[id]
 	if !exists {
-		return nil, fmt.Errorf("project not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, id)
 	}
 	return proj, nil
 }
 
 // StartProject starts all services in a compose project.
-func (m *Manager) StartProject(ctx context.Context, id string) error {
+// A non-empty host starts the project on that Docker host instead of the
+// one it was last seen on; this is refused while it runs elsewhere.
+// The compose result is returned whenever compose ran, even on failure.
+// Compose output lines are passed to onLine as they arrive, if non-nil.
+func (m *Manager) StartProject(
+	ctx context.Context,
+	id, hostName string,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
 	proj, err := m.GetProject(id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if proj.Source == model.SourceStandalone {
+		if hostName != "" && hostName != proj.Host {
+			return nil, fmt.Errorf(
+				"%w: moving standalone container %s to another host",
+				ErrUnsupported,
+				proj.Name,
+			)
+		}
+		return m.runStandalone(ctx, proj, ContainerStart, onLine)
+	}
+
+	if err := checkWritable(proj); err != nil {
+		return nil, err
+	}
+
+	if hostName != "" && hostName != proj.Host {
+		if _, err := m.host(hostName); err != nil {
+			return nil, err
+		}
+		if proj.Status != model.StatusStopped {
+			return nil, fmt.Errorf(
+				"project %s is running on host %s - stop it first",
+				proj.Name,
+				proj.Host,
+			)
+		}
+		m.mu.Lock()
+		proj.Host = hostName
+		m.mu.Unlock()
+	}
+
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := docker.ComposeUp(ctx, proj.ComposeFilePath)
+	result, err := h.client.ComposeUp(ctx, composeTarget(proj), onLine)
 	if err != nil {
-		return fmt.Errorf(
+		return result, fmt.Errorf(
 			"starting project %s: %w (output: %s)",
 			proj.Name,
 			err,
@@ -160,7 +273,7 @@ func (m *Manager) StartProject(ctx context.Context, id string) error {
 		)
 	}
 
-	return m.refreshProject(ctx, id)
+	return result, m.refreshProject(ctx, id)
 }
 
 // StopProject stops all services in a compose project.
@@ -169,23 +282,38 @@ func (m *Manager) StopProject(
 	ctx context.Context,
 	id string,
 	force bool,
-) error {
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
 	proj, err := m.GetProject(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if proj.Protected && !force {
-		return fmt.Errorf(
-			"project %s is protected (%s) - use force to override",
+		return nil, fmt.Errorf(
+			"%w: %s (%s) - use force to override",
+			ErrProjectProtected,
 			proj.Name,
 			proj.ProtectionReason,
 		)
 	}
 
-	result, err := docker.ComposeDown(ctx, proj.ComposeFilePath)
+	if proj.Source == model.SourceStandalone {
+		return m.runStandalone(ctx, proj, ContainerStop, onLine)
+	}
+
+	if err := checkWritable(proj); err != nil {
+		return nil, err
+	}
+
+	h, err := m.host(proj.Host)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, err
+	}
+
+	result, err := h.client.ComposeDown(ctx, composeTarget(proj), onLine)
+	if err != nil {
+		return result, fmt.Errorf(
 			"stopping project %s: %w (output: %s)",
 			proj.Name,
 			err,
@@ -193,27 +321,51 @@ func (m *Manager) StopProject(
 		)
 	}
 
-	return m.refreshProject(ctx, id)
+	// An external project only exists through its containers, so an event
+	// may already have dropped it once they are removed.
+	err = m.refreshProject(ctx, id)
+	if proj.Source == model.SourceExternal && errors.Is(err, ErrProjectNotFound) {
+		err = nil
+	}
+	return result, err
 }
 
 // RestartProject restarts all services in a compose project.
-func (m *Manager) RestartProject(ctx context.Context, id string) error {
+func (m *Manager) RestartProject(
+	ctx context.Context,
+	id string,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
 	proj, err := m.GetProject(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if proj.Protected {
-		return fmt.Errorf(
-			"project %s is protected (%s) - cannot restart",
+		return nil, fmt.Errorf(
+			"%w: %s (%s) - cannot restart",
+			ErrProjectProtected,
 			proj.Name,
 			proj.ProtectionReason,
 		)
 	}
 
-	result, err := docker.ComposeRestart(ctx, proj.ComposeFilePath)
+	if proj.Source == model.SourceStandalone {
+		return m.runStandalone(ctx, proj, ContainerRestart, onLine)
+	}
+
+	if err := checkWritable(proj); err != nil {
+		return nil, err
+	}
+
+	h, err := m.host(proj.Host)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, err
+	}
+
+	result, err := h.client.ComposeRestart(ctx, composeTarget(proj), onLine)
+	if err != nil {
+		return result, fmt.Errorf(
 			"restarting project %s: %w (output: %s)",
 			proj.Name,
 			err,
@@ -221,29 +373,117 @@ func (m *Manager) RestartProject(ctx context.Context, id string) error {
 		)
 	}
 
-	return m.refreshProject(ctx, id)
+	return result, m.refreshProject(ctx, id)
 }
 
-// SetProjectProtection enables or disables protection for a project.
+// PullProject pulls the latest images for a compose project without
+// recreating its containers.
+func (m *Manager) PullProject(
+	ctx context.Context,
+	id string,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if proj.Source == model.SourceStandalone {
+		return nil, fmt.Errorf(
+			"%w: pulling standalone container %s",
+			ErrUnsupported,
+			proj.Name,
+		)
+	}
+
+	if err := checkWritable(proj); err != nil {
+		return nil, err
+	}
+
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.client.ComposePull(ctx, composeTarget(proj), onLine)
+	if err != nil {
+		return result, fmt.Errorf(
+			"pulling project %s: %w (output: %s)",
+			proj.Name,
+			err,
+			result.Error,
+		)
+	}
+
+	return result, nil
+}
+
+// SetProjectProtection records an explicit protection decision for a
+// project. It overrides automatic detection until cleared, so setting
+// Protected false unprotects a project that matched a pattern.
 func (m *Manager) SetProjectProtection(
 	id string,
-	protected bool,
-	reason model.ProtectionReason,
+	rule model.ProtectionRule,
 ) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	proj, exists := m.projects[id]
 	if !exists {
-		return fmt.Errorf("project not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, id)
 	}
 
-	proj.Protected = protected
-	if protected {
-		proj.ProtectionReason = reason
-	} else {
-		proj.ProtectionReason = ""
+	if rule.Protected && rule.Reason == "" {
+		rule.Reason = model.ProtectionUserMarked
+	}
+	if !rule.Protected {
+		rule.Reason = ""
+	}
+	if rule.SetAt.IsZero() {
+		rule.SetAt = time.Now()
 	}
+
+	if m.store != nil {
+		if err := m.store.SetProtection(&store.ProjectProtection{
+			ProjectID: id,
+			Protected: rule.Protected,
+			Reason:    string(rule.Reason),
+			Note:      rule.Note,
+			SetBy:     rule.SetBy,
+			SetAt:     rule.SetAt,
+		}); err != nil {
+			return fmt.Errorf("saving protection: %w", err)
+		}
+	}
+
+	proj.ProtectionRule = &rule
+	m.applyProtection(proj)
+	proj.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ClearProjectProtection removes an explicit protection decision, returning
+// the project to config and pattern detection.
+func (m *Manager) ClearProjectProtection(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	proj, exists := m.projects[id]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, id)
+	}
+
+	if m.store != nil {
+		if err := m.store.DeleteProtection(id); err != nil {
+			return fmt.Errorf("clearing protection: %w", err)
+		}
+	}
+
+	proj.ProtectionRule = nil
+	proj.Protected = false
+	proj.ProtectionReason = ""
+	m.applyProtection(proj)
 	proj.UpdatedAt = time.Now()
 
 	return nil
@@ -256,7 +496,7 @@ func (m *Manager) SetProjectDisplayName(id, displayName string) error {
 
 	proj, exists := m.projects[id]
 	if !exists {
-		return fmt.Errorf("project not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, id)
 	}
 
 	if m.store != nil {
@@ -278,7 +518,7 @@ func (m *Manager) SetProjectHidden(id string, hidden bool) error {
 
 	proj, exists := m.projects[id]
 	if !exists {
-		return fmt.Errorf("project not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, id)
 	}
 
 	if m.store != nil {
@@ -303,13 +543,18 @@ func (m *Manager) GetProjectStats(
 		return nil, err
 	}
 
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return nil, err
+	}
+
 	stats := make(map[string]*model.ContainerStats)
 	for _, ctr := range proj.Containers {
 		if ctr.State != "running" {
 			continue
 		}
 
-		ctrStats, err := m.statsCollector.GetStats(ctx, ctr.ID)
+		ctrStats, err := h.statsCollector.GetStats(ctx, ctr.ID)
 		if err != nil {
 			continue
 		}
@@ -319,6 +564,58 @@ func (m *Manager) GetProjectStats(
 	return stats, nil
 }
 
+// CurrentStats returns stats for every running container, reusing samples
+// younger than maxAge (e.g. from the stats streamer or history recorder)
+// and fetching the rest concurrently per project.
+func (m *Manager) CurrentStats(
+	ctx context.Context,
+	maxAge time.Duration,
+) map[string]*model.ContainerStats {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result = make(map[string]*model.ContainerStats)
+	)
+
+	for _, proj := range m.ListProjects() {
+		h, err := m.host(proj.Host)
+		if err != nil {
+			continue
+		}
+
+		for _, ctr := range proj.Containers {
+			if ctr.State != "running" {
+				continue
+			}
+
+			if cached := h.statsCollector.Latest(ctr.ID); cached != nil &&
+				time.Since(cached.Timestamp) <= maxAge {
+				mu.Lock()
+				result[ctr.ID] = cached
+				mu.Unlock()
+				continue
+			}
+
+			wg.Add(1)
+			go func(containerID string) {
+				defer wg.Done()
+
+				stats, err := h.statsCollector.GetStats(ctx, containerID)
+				if err != nil {
+					return
+				}
+
+				mu.Lock()
+				result[containerID] = stats
+				mu.Unlock()
+			}(ctr.ID)
+		}
+	}
+
+	wg.Wait()
+	return result
+}
+
 // GetContainerLogs returns logs for a specific container.
 func (m *Manager) GetContainerLogs(
 	ctx context.Context,
@@ -328,29 +625,91 @@ func (m *Manager) GetContainerLogs(
 		Tail:       tail,
 		Timestamps: true,
 	}
-	return m.docker.GetLogs(ctx, containerID, opts)
+	return m.hostForContainer(containerID).client.GetLogs(
+		ctx,
+		containerID,
+		opts,
+	)
 }
 
-// GetSystemInfo returns Docker daemon information.
+// GetContainerLogLines returns buffered logs for a container as parsed,
+// timestamp-ordered lines.
+func (m *Manager) GetContainerLogLines(
+	ctx context.Context,
+	containerID string,
+	opts docker.LogOptions,
+) ([]docker.LogLine, error) {
+	opts.Timestamps = true
+	output, err := m.hostForContainer(containerID).client.GetLogs(
+		ctx,
+		containerID,
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := docker.SplitLogLines(docker.StreamStdout, output.Stdout)
+	lines = append(
+		lines,
+		docker.SplitLogLines(docker.StreamStderr, output.Stderr)...,
+	)
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+
+	return lines, nil
+}
+
+// StreamContainerLogs follows logs for a container on the host it runs on.
+func (m *Manager) StreamContainerLogs(
+	ctx context.Context,
+	containerID string,
+	opts docker.LogOptions,
+) (<-chan docker.LogLine, <-chan error) {
+	return m.hostForContainer(containerID).client.StreamLogs(
+		ctx,
+		containerID,
+		opts,
+	)
+}
+
+// GetSystemInfo returns Docker daemon information for a host.
+// An empty host name selects the default host.
 func (m *Manager) GetSystemInfo(
 	ctx context.Context,
+	hostName string,
 ) (*model.SystemInfo, error) {
-	return m.docker.GetSystemInfo(ctx)
+	h, err := m.host(hostName)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetSystemInfo(ctx)
 }
 
-// GetStorageInfo returns Docker storage usage.
+// GetStorageInfo returns Docker storage usage for a host.
 func (m *Manager) GetStorageInfo(
 	ctx context.Context,
+	hostName string,
 ) (*model.StorageInfo, error) {
-	return m.docker.GetStorageInfo(ctx)
+	h, err := m.host(hostName)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetStorageInfo(ctx)
 }
 
-// Prune removes unused Docker resources.
+// Prune removes unused Docker resources on a host.
 func (m *Manager) Prune(
 	ctx context.Context,
+	hostName string,
 	images, volumes, buildCache bool,
 ) (uint64, error) {
-	return m.docker.Prune(ctx, images, volumes, buildCache)
+	h, err := m.host(hostName)
+	if err != nil {
+		return 0, err
+	}
+	return h.client.Prune(ctx, images, volumes, buildCache)
 }
 
 // CheckPort checks if a port is available.
@@ -358,26 +717,37 @@ func (m *Manager) CheckPort(port uint16) *model.PortCheck {
 	return docker.CheckPort(port)
 }
 
-// StatsCollector returns the stats collector for streaming stats.
-func (m *Manager) StatsCollector() *docker.StatsCollector {
-	return m.statsCollector
-}
-
 func (m *Manager) refreshProject(ctx context.Context, id string) error {
-	containersByProject, err := m.docker.GetContainersByComposeProject(ctx)
+	proj, err := m.GetProject(id)
 	if err != nil {
 		return err
 	}
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return err
+	}
 
+	containersByProject, err := h.client.GetContainersByComposeProject(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
 	proj, exists := m.projects[id]
 	if !exists {
+		m.mu.Unlock()
 		return nil
 	}
 
-	projectName := docker.GetComposeProjectName(proj.ComposeFilePath)
+	if proj.Source == model.SourceStandalone {
+		refreshStandalone(proj, containersByProject)
+		proj.UpdatedAt = time.Now()
+		m.mu.Unlock()
+		return nil
+	}
+
+	projectName := m.composeNames[id]
 	if containers, ok := containersByProject[projectName]; ok {
 		proj.Containers = containers
 		proj.Status = determineProjectStatus(containers)
@@ -387,15 +757,27 @@ func (m *Manager) refreshProject(ctx context.Context, id string) error {
 	}
 
 	proj.UpdatedAt = time.Now()
+	m.mu.Unlock()
+
+	m.checkDrift(ctx, []string{id})
 	return nil
 }
 
+// applyProtection sets a project's protection from its explicit rule if it
+// has one, otherwise from the protected project list and name patterns.
+// Detected protection is sticky so it survives containers stopping.
 func (m *Manager) applyProtection(proj *model.Project) {
+	if rule := proj.ProtectionRule; rule != nil {
+		proj.Protected = rule.Protected
+		proj.ProtectionReason = rule.Reason
+		return
+	}
+
 	if proj.Protected {
 		return
 	}
 
-	if m.protection != nil {
+	if m.protection != nil && proj.Path != "" {
 		if m.protection.IsProtected(proj.Path) {
 			proj.Protected = true
 			proj.ProtectionReason = model.ProtectionUserMarked
@@ -418,6 +800,30 @@ func (m *Manager) applyProtection(proj *model.Project) {
 	}
 }
 
+// protectionRules loads persisted protection decisions keyed by project ID.
+func (m *Manager) protectionRules() map[string]*model.ProtectionRule {
+	rules := make(map[string]*model.ProtectionRule)
+	if m.store == nil {
+		return rules
+	}
+
+	protections, err := m.store.GetAllProtections()
+	if err != nil {
+		return rules
+	}
+
+	for id, p := range protections {
+		rules[id] = &model.ProtectionRule{
+			Protected: p.Protected,
+			Reason:    model.ProtectionReason(p.Reason),
+			Note:      p.Note,
+			SetBy:     p.SetBy,
+			SetAt:     p.SetAt,
+		}
+	}
+	return rules
+}
+
 func determineProjectStatus(containers []model.Container) model.ProjectStatus {
 	if len(containers) == 0 {
 		return model.StatusStopped
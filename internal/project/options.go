@@ -0,0 +1,182 @@
+/*
+AngelaMos | 2026
+options.go
+*/
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+var (
+	// ErrInvalidStack is returned when a chosen set of compose files is
+	// not available to a project.
+	ErrInvalidStack = errors.New("invalid compose file selection")
+	// ErrInvalidProfile is returned when a chosen profile is not declared
+	// by a project.
+	ErrInvalidProfile = errors.New("invalid compose profile")
+	// ErrInvalidEnvFile is returned when a chosen env file is not in a
+	// project's directory.
+	ErrInvalidEnvFile = errors.New("invalid env file")
+)
+
+// SetComposeFiles chooses which of a project's available compose files it
+// is loaded and run from, in order, like repeated -f flags. An empty list
+// restores the default: the base file and its override. The choice is
+// persisted and the projects are rescanned.
+func (m *Manager) SetComposeFiles(
+	ctx context.Context,
+	id string,
+	files []string,
+) error {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	available := proj.AvailableFiles
+	m.mu.RUnlock()
+
+	if len(available) == 0 {
+		return fmt.Errorf(
+			"%w: %s has no other compose files to choose from",
+			ErrUnsupported,
+			proj.Name,
+		)
+	}
+
+	for i, name := range files {
+		if !slices.Contains(available, name) {
+			return fmt.Errorf("%w: %s is not a compose file of %s",
+				ErrInvalidStack, name, proj.Name)
+		}
+		if slices.Contains(files[:i], name) {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidStack, name)
+		}
+	}
+
+	if m.store != nil {
+		if err := m.store.SetComposeFiles(id, files); err != nil {
+			return err
+		}
+	}
+
+	m.scanner.SetFiles(id, files)
+	return m.Refresh(ctx)
+}
+
+// SetProfiles chooses which of the compose profiles a project declares
+// are active. Services behind inactive profiles are left out of the
+// project and --profile flags are passed to every compose command. The
+// choice is persisted and the projects are rescanned.
+func (m *Manager) SetProfiles(
+	ctx context.Context,
+	id string,
+	profiles []string,
+) error {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	declared := proj.Profiles
+	m.mu.RUnlock()
+
+	for i, profile := range profiles {
+		if !slices.Contains(declared, profile) {
+			return fmt.Errorf("%w: %s declares no profile %s",
+				ErrInvalidProfile, proj.Name, profile)
+		}
+		if slices.Contains(profiles[:i], profile) {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidProfile, profile)
+		}
+	}
+
+	if m.store != nil {
+		if err := m.store.SetProfiles(id, profiles); err != nil {
+			return err
+		}
+	}
+
+	m.scanner.SetProfiles(id, profiles)
+	return m.Refresh(ctx)
+}
+
+// SetEnvFiles chooses which env files in a project's directory its
+// compose files are interpolated with, in order, like repeated --env-file
+// flags. An empty list restores the default: the .env file, if any. The
+// choice is persisted and the projects are rescanned.
+func (m *Manager) SetEnvFiles(
+	ctx context.Context,
+	id string,
+	files []string,
+) error {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	source, dir := proj.Source, proj.Path
+	m.mu.RUnlock()
+
+	if source != model.SourceCompose {
+		return fmt.Errorf("%w: env files of %s project %s",
+			ErrUnsupported, source, proj.Name)
+	}
+
+	available := scanner.DetectEnvFiles(dir)
+	for i, name := range files {
+		if !slices.Contains(available, name) {
+			return fmt.Errorf("%w: %s is not an env file of %s",
+				ErrInvalidEnvFile, name, proj.Name)
+		}
+		if slices.Contains(files[:i], name) {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidEnvFile, name)
+		}
+	}
+
+	if m.store != nil {
+		if err := m.store.SetEnvFiles(id, files); err != nil {
+			return err
+		}
+	}
+
+	m.scanner.SetEnvFiles(id, files)
+	return m.Refresh(ctx)
+}
+
+// projectOptions collects the load options chosen per project.
+func projectOptions(
+	prefs map[string]*store.ProjectPreference,
+) map[string]scanner.ProjectOptions {
+	options := make(map[string]scanner.ProjectOptions, len(prefs))
+	for id, pref := range prefs {
+		options[id] = scanner.ProjectOptions{
+			Files:    pref.ComposeFiles,
+			Profiles: pref.Profiles,
+			EnvFiles: pref.EnvFiles,
+		}
+	}
+	return options
+}
+
+// composeTarget is what compose commands for a project run against.
+func composeTarget(proj *model.Project) docker.ComposeTarget {
+	return docker.ComposeTarget{
+		Files:    proj.ComposeFiles,
+		Profiles: proj.ActiveProfiles,
+		EnvFiles: envFilePaths(proj),
+	}
+}
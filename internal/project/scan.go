@@ -0,0 +1,79 @@
+/*
+AngelaMos | 2026
+scan.go
+*/
+
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ScanStatus describes the scanner's configuration and its last completed
+// scan. ScannedAt is zero until the first scan finishes.
+type ScanStatus struct {
+	Paths      []string              `json:"paths"`
+	Watching   bool                  `json:"watching"`
+	ScannedAt  time.Time             `json:"scanned_at"`
+	DurationMS int64                 `json:"duration_ms"`
+	DiscoverMS int64                 `json:"discover_ms"`
+	ParseMS    int64                 `json:"parse_ms"`
+	Files      int                   `json:"files"`
+	Projects   int                   `json:"projects"`
+	Errors     []scanner.ScanError   `json:"errors"`
+	Warnings   []scanner.ScanWarning `json:"warnings"`
+}
+
+// ScanStatus returns the scan paths and the outcome of the last scan,
+// including files that look like compose files but failed to load and
+// warnings about projects that did.
+func (m *Manager) ScanStatus() ScanStatus {
+	status := ScanStatus{
+		Paths:    m.scanner.GetPaths(),
+		Watching: m.scanner.Watching(),
+		Errors:   []scanner.ScanError{},
+		Warnings: []scanner.ScanWarning{},
+	}
+
+	m.mu.RLock()
+	result, scannedAt := m.lastScan, m.lastScanAt
+	m.mu.RUnlock()
+
+	if result == nil {
+		return status
+	}
+
+	status.ScannedAt = scannedAt
+	status.DurationMS = result.Duration.Milliseconds()
+	status.DiscoverMS = result.Timings.Discover.Milliseconds()
+	status.ParseMS = result.Timings.Parse.Milliseconds()
+	status.Files = result.Files
+	status.Projects = len(result.Projects)
+	status.Errors = append(status.Errors, result.Errors...)
+	status.Warnings = append(status.Warnings, result.Warnings...)
+	return status
+}
+
+// Rescan drops the scanner's parse cache and file index so the scan paths
+// are walked and every compose file is loaded again, then refreshes all
+// projects.
+func (m *Manager) Rescan(ctx context.Context) error {
+	m.scanner.ClearCache()
+	return m.Refresh(ctx)
+}
+
+// SetScanPaths validates and replaces the scan paths, then rescans. The
+// change lasts until restart; the config file remains the source of the
+// paths used at startup.
+func (m *Manager) SetScanPaths(ctx context.Context, paths []string) error {
+	cleaned, err := scanner.ValidatePaths(paths)
+	if err != nil {
+		return err
+	}
+
+	m.scanner.SetPaths(cleaned)
+	return m.Rescan(ctx)
+}
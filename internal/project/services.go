@@ -0,0 +1,166 @@
+/*
+AngelaMos | 2026
+services.go
+*/
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/scanner"
+)
+
+// ErrServiceNotFound is returned when a project has no service by that name.
+var ErrServiceNotFound = errors.New("service not found")
+
+// guardedServiceActions interrupt a running service and so are refused
+// on protected services.
+var guardedServiceActions = map[docker.ServiceAction]bool{
+	docker.ServiceStop:     true,
+	docker.ServiceRestart:  true,
+	docker.ServiceRecreate: true,
+}
+
+// IsGuardedServiceAction reports whether protection applies to action.
+func IsGuardedServiceAction(action docker.ServiceAction) bool {
+	return guardedServiceActions[action]
+}
+
+// ServiceProtection reports whether a single service is protected. An
+// explicit project rule applies to all its services; otherwise a service
+// is protected if its project is on the protected list or one of its own
+// containers matches a protection pattern. Unlike project protection this
+// ignores the project's other services, so a tunnel sidecar does not lock
+// the application next to it.
+func (m *Manager) ServiceProtection(
+	id, service string,
+) (bool, model.ProtectionReason, error) {
+	proj, err := m.getService(id, service)
+	if err != nil {
+		return false, "", err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if rule := proj.ProtectionRule; rule != nil {
+		return rule.Protected, rule.Reason, nil
+	}
+
+	if m.protection.IsProtected(proj.Path) {
+		return true, model.ProtectionUserMarked, nil
+	}
+
+	for _, ctr := range proj.Containers {
+		if ctr.ServiceName != service {
+			continue
+		}
+		if protected, reason := scanner.IsProtectedByPattern(ctr.Name); protected {
+			return true, reason, nil
+		}
+		if protected, reason := scanner.IsProtectedByPattern(ctr.Image); protected {
+			return true, reason, nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// RunServiceAction runs a compose action against one service of a
+// project. Stop, restart and recreate are refused on protected services;
+// force overrides that for stop only, matching StopProject. Only this
+// project is refreshed afterwards.
+func (m *Manager) RunServiceAction(
+	ctx context.Context,
+	id, service string,
+	action docker.ServiceAction,
+	force bool,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown service action: %s", action)
+	}
+
+	proj, err := m.getService(id, service)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsGuardedServiceAction(action) &&
+		!(force && action == docker.ServiceStop) {
+		protected, reason, err := m.ServiceProtection(id, service)
+		if err != nil {
+			return nil, err
+		}
+		if protected {
+			return nil, fmt.Errorf(
+				"%w: service %s of %s (%s) - cannot %s",
+				ErrProjectProtected,
+				service,
+				proj.Name,
+				reason,
+				action,
+			)
+		}
+	}
+
+	if err := checkWritable(proj); err != nil {
+		return nil, err
+	}
+
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.client.ComposeService(
+		ctx,
+		composeTarget(proj),
+		service,
+		action,
+		onLine,
+	)
+	if err != nil {
+		if result == nil {
+			return nil, fmt.Errorf("%s service %s: %w", action, service, err)
+		}
+		return result, fmt.Errorf(
+			"%s service %s of %s: %w (output: %s)",
+			action,
+			service,
+			proj.Name,
+			err,
+			result.Error,
+		)
+	}
+
+	if action == docker.ServicePull || action == docker.ServiceBuild {
+		return result, nil
+	}
+
+	return result, m.refreshProject(ctx, id)
+}
+
+// getService returns the project after checking it defines service.
+func (m *Manager) getService(id, service string) (*model.Project, error) {
+	proj, err := m.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defined := slices.Contains(proj.Services, service)
+	m.mu.RUnlock()
+
+	if !defined {
+		return nil, fmt.Errorf("%w: %s in %s", ErrServiceNotFound, service, proj.Name)
+	}
+
+	return proj, nil
+}
@@ -0,0 +1,194 @@
+/*
+AngelaMos | 2026
+standalone.go
+*/
+
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/store"
+)
+
+// standaloneGroup is the bucket GetContainersByComposeProject uses for
+// containers without a compose project label. Standalone projects map to
+// it in composeNames so events on their containers are routed here.
+const standaloneGroup = "_standalone"
+
+// ErrUnsupported is returned for operations that do not apply to a
+// project's source, such as pulling a standalone container.
+var ErrUnsupported = errors.New("operation not supported")
+
+// standaloneID derives a stable project ID from the container name, so
+// preferences and protection rules survive the container being recreated.
+func standaloneID(hostName, containerName string) string {
+	hash := sha256.Sum256([]byte("standalone/" + hostName + "/" + containerName))
+	return hex.EncodeToString(hash[:8])
+}
+
+func newStandaloneProject(hostName string, ctr model.Container) *model.Project {
+	containers := []model.Container{ctr}
+	return &model.Project{
+		ID:          standaloneID(hostName, ctr.Name),
+		Name:        ctr.Name,
+		Source:      model.SourceStandalone,
+		Host:        hostName,
+		Environment: model.EnvUnknown,
+		Status:      determineProjectStatus(containers),
+		Containers:  containers,
+		Services:    []string{},
+		CreatedAt:   ctr.CreatedAt,
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// addStandaloneLocked creates one project per unlabelled container on each
+// host, carrying over preferences, rules and sticky protection like
+// scanned projects. Callers hold the manager lock.
+func (m *Manager) addStandaloneLocked(
+	projects map[string]*model.Project,
+	composeNames map[string]string,
+	containersByHost map[string]map[string][]model.Container,
+	prefs map[string]*store.ProjectPreference,
+	rules map[string]*model.ProtectionRule,
+) {
+	for _, hostName := range m.hostOrder {
+		for _, ctr := range containersByHost[hostName][standaloneGroup] {
+			proj := newStandaloneProject(hostName, ctr)
+			m.adoptLocked(proj, prefs, rules)
+			projects[proj.ID] = proj
+			composeNames[proj.ID] = standaloneGroup
+		}
+	}
+}
+
+// patchStandalone re-lists unlabelled containers on a host after an event,
+// updating, adding and dropping standalone projects to match.
+func (m *Manager) patchStandalone(ctx context.Context, hostName string) {
+	h, err := m.host(hostName)
+	if err != nil {
+		return
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, eventPatchDeadline)
+	defer cancel()
+
+	grouped, err := h.client.GetContainersByComposeProject(listCtx)
+	if err != nil {
+		slog.Default().Warn("patching standalone containers from event",
+			"host", hostName,
+			"error", err,
+		)
+		return
+	}
+
+	var prefs map[string]*store.ProjectPreference
+	if m.store != nil {
+		prefs, _ = m.store.GetAllPreferences()
+	}
+	rules := m.protectionRules()
+
+	m.mu.Lock()
+	// Only this host's standalone projects are rebuilt, so only they can
+	// change or go away.
+	previous := make(map[string]projectState)
+	for id, proj := range m.projects {
+		if proj.Source == model.SourceStandalone && proj.Host == hostName {
+			previous[id] = stateOf(proj)
+		}
+	}
+
+	current := make(map[string]*model.Project)
+	hostOnly := map[string]map[string][]model.Container{hostName: grouped}
+	m.addStandaloneLocked(current, m.composeNames, hostOnly, prefs, rules)
+
+	for id, proj := range m.projects {
+		if proj.Source != model.SourceStandalone || proj.Host != hostName {
+			continue
+		}
+		if _, ok := current[id]; !ok {
+			delete(m.projects, id)
+			delete(m.composeNames, id)
+		}
+	}
+	for id, proj := range current {
+		m.projects[id] = proj
+	}
+	m.mu.Unlock()
+
+	m.notifyChanges(previous, current)
+}
+
+// refreshStandalone updates a standalone project's container from a fresh
+// listing of the host. A container that vanished leaves the project
+// stopped until the next full refresh drops it.
+func refreshStandalone(proj *model.Project, grouped map[string][]model.Container) {
+	proj.Containers = []model.Container{}
+	for _, ctr := range grouped[standaloneGroup] {
+		if ctr.Name == proj.Name {
+			proj.Containers = append(proj.Containers, ctr)
+			break
+		}
+	}
+	proj.Status = determineProjectStatus(proj.Containers)
+}
+
+// runStandalone performs a project-level lifecycle action on the container
+// behind a standalone project, reporting the outcome in compose terms so
+// callers can treat both kinds of project alike.
+func (m *Manager) runStandalone(
+	ctx context.Context,
+	proj *model.Project,
+	action ContainerAction,
+	onLine docker.LineFunc,
+) (*docker.ComposeResult, error) {
+	if len(proj.Containers) == 0 {
+		return nil, fmt.Errorf(
+			"%w: %s",
+			ErrContainerNotFound,
+			proj.Name,
+		)
+	}
+
+	h, err := m.host(proj.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	ctr := proj.Containers[0]
+	switch action {
+	case ContainerStart:
+		err = h.client.StartContainer(ctx, ctr.ID)
+	case ContainerStop:
+		err = h.client.StopContainer(ctx, ctr.ID, defaultStopTimeout)
+	case ContainerRestart:
+		err = h.client.RestartContainer(ctx, ctr.ID, defaultStopTimeout)
+	default:
+		return nil, fmt.Errorf("%w: %s on standalone container", ErrUnsupported, action)
+	}
+
+	if err != nil {
+		return &docker.ComposeResult{Error: err.Error()}, err
+	}
+
+	text := fmt.Sprintf("container %s: %s done", ctr.Name, action)
+	if onLine != nil {
+		onLine(docker.LogLine{
+			Stream:    docker.StreamStdout,
+			Timestamp: time.Now(),
+			Text:      text,
+		})
+	}
+
+	result := &docker.ComposeResult{Success: true, Output: text}
+	return result, m.refreshProject(ctx, proj.ID)
+}
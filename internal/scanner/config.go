@@ -0,0 +1,295 @@
+/*
+AngelaMos | 2026
+config.go
+*/
+
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/loader"
+	"github.com/compose-spec/compose-go/v2/schema"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+// composeSchema is the compose specification schema, compiled on first
+// use.
+var composeSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema.Schema))
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("compose-spec.json", doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile("compose-spec.json")
+})
+
+// loadProject loads compose files the way docker compose run with the
+// same -f, --env-file and --profile flags would.
+func loadProject(
+	ctx context.Context,
+	files, envFiles, profiles []string,
+	extra ...cli.ProjectOptionsFn,
+) (*types.Project, error) {
+	opts, err := cli.NewProjectOptions(
+		files,
+		append([]cli.ProjectOptionsFn{
+			cli.WithOsEnv,
+			cli.WithEnvFiles(envFiles...),
+			cli.WithDotEnv,
+			cli.WithResolvedPaths(true),
+			cli.WithInterpolation(true),
+			cli.WithProfiles(profiles),
+		}, extra...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return opts.LoadProject(ctx)
+}
+
+// ResolveConfig returns the fully resolved compose model of a project:
+// merged, interpolated and with defaults applied. Secret-looking values
+// of service environments and build args are masked, as in InspectEnv.
+func ResolveConfig(
+	ctx context.Context,
+	files, envFiles, profiles []string,
+) (*types.Project, error) {
+	restore := silenceStderr()
+	defer restore()
+
+	project, err := loadProject(ctx, files, envFiles, profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range project.Services {
+		maskMapping(svc.Environment)
+		if svc.Build != nil {
+			maskMapping(svc.Build.Args)
+		}
+	}
+	return project, nil
+}
+
+func maskMapping(mapping types.MappingWithEquals) {
+	for name, value := range mapping {
+		if value == nil {
+			continue
+		}
+		if masked, ok := MaskValue(name, *value); ok {
+			mapping[name] = &masked
+		}
+	}
+}
+
+// ValidateConfig checks a project's compose files for what would make
+// docker compose up fail or misbehave: keys the compose specification
+// does not know, variables that are referenced but unset, and build
+// contexts or Dockerfiles that do not exist. Build contexts are only
+// checked once the files load, so a required variable left unset hides
+// them until it is set.
+func ValidateConfig(
+	ctx context.Context,
+	files, envFiles, profiles []string,
+) []model.ConfigIssue {
+	issues := make([]model.ConfigIssue, 0)
+	for _, file := range files {
+		issues = append(issues, unknownKeys(file)...)
+	}
+
+	if variables, _, err := InspectEnv(ctx, files, envFiles); err == nil {
+		issues = append(issues, unsetVariables(variables)...)
+	}
+
+	restore := silenceStderr()
+	defer restore()
+
+	project, err := loadProject(ctx, files, envFiles, profiles)
+	if err != nil {
+		// Unknown keys fail the load too; report other failures only.
+		if !hasErrors(issues) {
+			issues = append(issues, model.ConfigIssue{
+				Severity: model.IssueError,
+				Kind:     model.IssueInvalid,
+				Message:  err.Error(),
+			})
+		}
+		project, err = loadProject(ctx, files, envFiles, profiles,
+			cli.WithLoadOptions(loader.WithSkipValidation))
+		if err != nil {
+			return issues
+		}
+	}
+
+	return append(issues, buildContextIssues(project)...)
+}
+
+// unknownKeys validates a single compose file against the compose
+// specification and reports every key it does not allow. Files that do
+// not parse are left for the loader to report.
+func unknownKeys(file string) []model.ConfigIssue {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return []model.ConfigIssue{{
+			Severity: model.IssueError,
+			Kind:     model.IssueInvalid,
+			File:     filepath.Base(file),
+			Message:  err.Error(),
+		}}
+	}
+
+	raw, err := yaml.Parser().Unmarshal(data)
+	if err != nil {
+		return nil
+	}
+
+	// The schema validates plain JSON values only.
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var doc any
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return nil
+	}
+
+	compiled, err := composeSchema()
+	if err != nil {
+		return nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(compiled.Validate(doc), &verr) {
+		return nil
+	}
+
+	found := make(map[string]bool)
+	collectUnknownKeys(verr, found)
+
+	paths := make([]string, 0, len(found))
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	issues := make([]model.ConfigIssue, len(paths))
+	for i, path := range paths {
+		issues[i] = model.ConfigIssue{
+			Severity: model.IssueError,
+			Kind:     model.IssueUnknownKey,
+			Path:     path,
+			File:     filepath.Base(file),
+			Message:  fmt.Sprintf("%s is not a compose key", path),
+		}
+	}
+	return issues
+}
+
+func collectUnknownKeys(verr *jsonschema.ValidationError, found map[string]bool) {
+	if extra, ok := verr.ErrorKind.(*kind.AdditionalProperties); ok {
+		for _, key := range extra.Properties {
+			path := append(append([]string{}, verr.InstanceLocation...), key)
+			found[strings.Join(path, ".")] = true
+		}
+	}
+	for _, cause := range verr.Causes {
+		collectUnknownKeys(cause, found)
+	}
+}
+
+// unsetVariables reports referenced variables without a value. Compose
+// refuses to start with a required one unset and substitutes an empty
+// string for the rest.
+func unsetVariables(variables []model.EnvVariable) []model.ConfigIssue {
+	issues := make([]model.ConfigIssue, 0)
+	for _, variable := range variables {
+		if variable.Source != model.EnvSourceUnset {
+			continue
+		}
+		issue := model.ConfigIssue{
+			Severity: model.IssueWarning,
+			Kind:     model.IssueInterpolation,
+			Message: fmt.Sprintf(
+				"%s is not set, defaulting to a blank string",
+				variable.Name,
+			),
+		}
+		if variable.Required {
+			issue.Severity = model.IssueError
+			issue.Message = fmt.Sprintf("required variable %s is not set", variable.Name)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// buildContextIssues reports local build contexts and Dockerfiles that
+// do not exist. Remote contexts, such as git URLs, are not checked.
+func buildContextIssues(project *types.Project) []model.ConfigIssue {
+	issues := make([]model.ConfigIssue, 0)
+	for _, name := range project.ServiceNames() {
+		build := project.Services[name].Build
+		if build == nil || !filepath.IsAbs(build.Context) {
+			continue
+		}
+
+		path := "services." + name + ".build"
+		info, err := os.Stat(build.Context)
+		if err != nil || !info.IsDir() {
+			issues = append(issues, model.ConfigIssue{
+				Severity: model.IssueError,
+				Kind:     model.IssueBuildContext,
+				Path:     path + ".context",
+				Message:  fmt.Sprintf("build context %s does not exist", build.Context),
+			})
+			continue
+		}
+
+		if build.DockerfileInline != "" {
+			continue
+		}
+		dockerfile := build.Dockerfile
+		if dockerfile == "" {
+			dockerfile = "Dockerfile"
+		}
+		if !filepath.IsAbs(dockerfile) {
+			dockerfile = filepath.Join(build.Context, dockerfile)
+		}
+		if _, err := os.Stat(dockerfile); err != nil {
+			issues = append(issues, model.ConfigIssue{
+				Severity: model.IssueError,
+				Kind:     model.IssueBuildContext,
+				Path:     path + ".dockerfile",
+				Message:  fmt.Sprintf("Dockerfile %s does not exist", dockerfile),
+			})
+		}
+	}
+	return issues
+}
+
+func hasErrors(issues []model.ConfigIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == model.IssueError {
+			return true
+		}
+	}
+	return false
+}
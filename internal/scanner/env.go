@@ -0,0 +1,163 @@
+/*
+AngelaMos | 2026
+env.go
+*/
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/dotenv"
+	"github.com/compose-spec/compose-go/v2/loader"
+	"github.com/compose-spec/compose-go/v2/template"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+const maskedValue = "********"
+
+// secretPatterns mark variable names whose values are masked.
+var secretPatterns = []string{
+	"password",
+	"passwd",
+	"pass",
+	"secret",
+	"token",
+	"key",
+	"private",
+	"credential",
+	"auth",
+	"cert",
+	"salt",
+	"dsn",
+}
+
+// DetectEnvFiles lists the env files in a directory: .env, .env.<name>
+// and <name>.env.
+func DetectEnvFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []string{}
+	}
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		if name == ".env" || strings.HasPrefix(name, ".env.") ||
+			strings.HasSuffix(name, ".env") {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// InspectEnv reports every variable the compose files reference and where
+// its value comes from: the process environment, which takes precedence
+// as it does for compose, then the env files, later files winning, then
+// the reference's own default. With no env files given, the .env file
+// next to the first compose file is used if present, as compose does.
+// It also returns the env files that were read. Keys the compose
+// specification does not know are tolerated; see ValidateConfig.
+func InspectEnv(
+	ctx context.Context,
+	composeFiles, envFiles []string,
+) ([]model.EnvVariable, []string, error) {
+	opts, err := cli.NewProjectOptions(
+		composeFiles,
+		cli.WithEnvFiles(envFiles...),
+		cli.WithInterpolation(false),
+		cli.WithLoadOptions(loader.WithSkipValidation),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := opts.LoadModel(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileValues := make([]map[string]string, len(opts.EnvFiles))
+	for i, file := range opts.EnvFiles {
+		values, err := dotenv.Read(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading env file %s: %w", file, err)
+		}
+		fileValues[i] = values
+	}
+
+	referenced := template.ExtractVariables(raw, template.DefaultPattern)
+	variables := make([]model.EnvVariable, 0, len(referenced))
+
+	for name, ref := range referenced {
+		variable := model.EnvVariable{
+			Name:     name,
+			Source:   model.EnvSourceUnset,
+			Required: ref.Required,
+		}
+
+		if value, ok := os.LookupEnv(name); ok {
+			variable.Source = model.EnvSourceEnvironment
+			variable.Value = value
+		} else {
+			for i := len(fileValues) - 1; i >= 0; i-- {
+				if value, ok := fileValues[i][name]; ok {
+					variable.Source = model.EnvSourceFile
+					variable.File = filepath.Base(opts.EnvFiles[i])
+					variable.Value = value
+					break
+				}
+			}
+		}
+
+		if variable.Source == model.EnvSourceUnset && ref.DefaultValue != "" {
+			variable.Source = model.EnvSourceDefault
+			variable.Value = ref.DefaultValue
+		}
+
+		variable.Value, variable.Masked = MaskValue(name, variable.Value)
+		variables = append(variables, variable)
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+
+	return variables, opts.EnvFiles, nil
+}
+
+// MaskValue hides the value of a secret-looking variable, and the password
+// of a URL with credentials in any variable. It reports whether the value
+// was masked.
+func MaskValue(name, value string) (string, bool) {
+	if value == "" {
+		return value, false
+	}
+
+	lower := strings.ToLower(name)
+	for _, pattern := range secretPatterns {
+		if strings.Contains(lower, pattern) {
+			return maskedValue, true
+		}
+	}
+
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return u.Redacted(), true
+		}
+	}
+
+	return value, false
+}
@@ -9,37 +9,88 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/loader"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/fsnotify/fsnotify"
 
 	"github.com/carterperez-dev/holophyly/internal/model"
 )
 
+// ErrInvalidPath is returned by ValidatePaths for unusable scan paths.
+var ErrInvalidPath = errors.New("invalid scan path")
+
+// stderrMu guards swapping os.Stderr; see silenceStderr.
+var stderrMu sync.Mutex
+
 type Scanner struct {
 	paths   []string
 	exclude []string
 	mu      sync.RWMutex
 	cache   map[string]*CachedProject
+	// watcher, index and dirty are maintained while watching; see Watch.
+	// A nil index means the next scan has to walk the paths.
+	watcher *fsnotify.Watcher
+	index   map[string]bool
+	dirty   map[string]bool
+	// options holds the choices made per project ID; see ProjectOptions.
+	options map[string]ProjectOptions
 }
 
+// CachedProject is the last parse of a stack of YAML files, keyed by the
+// stack's key. Project is nil for files that are not compose files or
+// failed to parse, so they are not parsed again until they change; Error
+// keeps the failure to report. Model is the compose model Project was
+// loaded from; Warnings are problems in it that do not stop it loading.
 type CachedProject struct {
 	Project  *model.Project
+	Model    *types.Project
+	Error    string
+	Warnings []string
+	Files    []CachedFile
+	Profiles []string
+	EnvFiles []string
+	// Env is the state of the env files the parse read. A missing .env
+	// is kept too, so creating one is noticed.
+	Env []CachedFile
+}
+
+// CachedFile is the state of one file of a stack when it was parsed.
+type CachedFile struct {
+	Path     string
 	ModTime  time.Time
+	Size     int64
 	CheckSum string
 }
 
 type ScanResult struct {
 	Projects []*model.Project
 	Errors   []ScanError
+	Warnings []ScanWarning
+	Files    int
 	Duration time.Duration
+	Timings  ScanTimings
+}
+
+// ScanTimings break a scan's duration down by phase. Discover is the walk,
+// or the index lookup while watching; Parse covers compose files that were
+// new or changed.
+type ScanTimings struct {
+	Discover time.Duration
+	Parse    time.Duration
 }
 
 type ScanError struct {
@@ -47,6 +98,13 @@ type ScanError struct {
 	Error string `json:"error"`
 }
 
+// ScanWarning is a problem in a compose project that loaded, such as a
+// dependency cycle, which docker compose up would still refuse.
+type ScanWarning struct {
+	Path    string `json:"path"`
+	Warning string `json:"warning"`
+}
+
 // NewScanner creates a scanner for discovering compose files.
 func NewScanner(paths, exclude []string) *Scanner {
 	if len(exclude) == 0 {
@@ -57,23 +115,149 @@ func NewScanner(paths, exclude []string) *Scanner {
 		paths:   paths,
 		exclude: exclude,
 		cache:   make(map[string]*CachedProject),
+		options: make(map[string]ProjectOptions),
 	}
 }
 
 // Scan discovers all compose files in configured paths.
 // Uses content-based detection - parses YAML and checks for services key.
+// Compose files in one directory are grouped into a single project; see
+// groupStacks. While watching, the file index replaces the directory walk
+// and only stacks with files changed since the last scan are looked at
+// again. Stacks that need parsing are parsed in parallel.
 func (s *Scanner) Scan(ctx context.Context) (*ScanResult, error) {
 	start := time.Now()
 	result := &ScanResult{
 		Projects: make([]*model.Project, 0),
 		Errors:   make([]ScanError, 0),
+		Warnings: make([]ScanWarning, 0),
+	}
+
+	yamlFiles, dirty, indexed := s.indexedFiles()
+	if !indexed {
+		var dirs []string
+		yamlFiles, dirs, result.Errors = s.walk(ctx)
+		// A walk cut short lists only part of the tree; indexing it would
+		// hide the rest from every later scan.
+		if ctx.Err() == nil && len(result.Errors) == 0 {
+			s.rebuildIndex(yamlFiles, dirs)
+		}
+	}
+	result.Files = len(yamlFiles)
+	stacks := s.groupStacks(yamlFiles)
+	result.Timings.Discover = time.Since(start)
+
+	// Keep discovery order; parsed slots are filled in by the workers.
+	projects := make([]*model.Project, len(stacks))
+	errs := make([]error, len(stacks))
+	pending := make([]int, 0, len(stacks))
+
+	for i, stack := range stacks {
+		if indexed && !stack.changed(dirty) {
+			if cached := s.cached(stack.key); cached.matches(stack) {
+				projects[i] = cached.Project
+				errs[i] = cached.err()
+				continue
+			}
+		}
+		pending = append(pending, i)
 	}
 
-	logger := slog.Default()
+	parseStart := time.Now()
+	s.parseAll(ctx, stacks, pending, projects, errs)
+	result.Timings.Parse = time.Since(parseStart)
 
+	for i, proj := range projects {
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, ScanError{
+				Path:  stacks[i].key,
+				Error: errs[i].Error(),
+			})
+		}
+		if proj != nil {
+			result.Projects = append(result.Projects, proj)
+		}
+		if cached := s.cached(stacks[i].key); proj != nil && cached != nil {
+			for _, warning := range cached.Warnings {
+				result.Warnings = append(result.Warnings, ScanWarning{
+					Path:    stacks[i].key,
+					Warning: warning,
+				})
+			}
+		}
+	}
+
+	result.Duration = time.Since(start)
+
+	if err := ctx.Err(); err != nil {
+		s.restoreDirty(dirty)
+		return result, err
+	}
+	return result, nil
+}
+
+// parseAll parses the stacks at the pending indexes with a bounded pool of
+// workers, storing each compose project or parse error at its index in
+// projects or errs.
+func (s *Scanner) parseAll(
+	ctx context.Context,
+	stacks []composeStack,
+	pending []int,
+	projects []*model.Project,
+	errs []error,
+) {
+	if len(pending) == 0 {
+		return
+	}
+
+	restore := silenceStderr()
+	defer restore()
+
+	workers := min(runtime.NumCPU(), len(pending))
+	work := make(chan int)
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				proj, err := s.parseStack(ctx, stacks[i])
+				if err != nil {
+					slog.Default().Debug("skipping compose file",
+						"path", stacks[i].key,
+						"error", err,
+					)
+					errs[i] = err
+					continue
+				}
+				projects[i] = proj
+			}
+		}()
+	}
+
+feed:
+	for _, i := range pending {
+		select {
+		case <-ctx.Done():
+			break feed
+		case work <- i:
+		}
+	}
+	close(work)
+	wg.Wait()
+}
+
+// walk lists YAML files and directories under the scan paths. While
+// watching, each directory is watched as it is visited, so changes made
+// after it was listed are not missed.
+func (s *Scanner) walk(ctx context.Context) ([]string, []string, []ScanError) {
 	yamlFiles := make([]string, 0)
+	dirs := make([]string, 0)
+	errs := make([]ScanError, 0)
+	watching := s.Watching()
 
-	for _, scanPath := range s.paths {
+	for _, scanPath := range s.GetPaths() {
 		expanded := expandPath(scanPath)
 
 		err := filepath.WalkDir(
@@ -93,6 +277,10 @@ func (s *Scanner) Scan(ctx context.Context) (*ScanResult, error) {
 					if s.shouldExcludeDir(d.Name()) {
 						return filepath.SkipDir
 					}
+					dirs = append(dirs, path)
+					if watching && !s.addWatch(path) {
+						watching = false
+					}
 					return nil
 				}
 
@@ -105,117 +293,153 @@ func (s *Scanner) Scan(ctx context.Context) (*ScanResult, error) {
 		)
 
 		if err != nil && err != context.Canceled {
-			result.Errors = append(result.Errors, ScanError{
+			errs = append(errs, ScanError{
 				Path:  scanPath,
 				Error: fmt.Sprintf("walking directory: %v", err),
 			})
 		}
 	}
 
-	for _, yamlPath := range yamlFiles {
-		select {
-		case <-ctx.Done():
-			result.Duration = time.Since(start)
-			return result, ctx.Err()
-		default:
-		}
-
-		proj, err := s.parseComposeFile(ctx, yamlPath)
-		if err != nil {
-			logger.Debug("skipping compose file", "path", yamlPath, "error", err)
-			continue
-		}
-
-		if proj != nil {
-			result.Projects = append(result.Projects, proj)
-		}
-	}
+	return yamlFiles, dirs, errs
+}
 
-	result.Duration = time.Since(start)
-	return result, nil
+func (s *Scanner) cached(path string) *CachedProject {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache[path]
 }
 
-// parseComposeFile attempts to parse a YAML file as a compose file.
-// Returns nil if the file is not a valid compose file.
-func (s *Scanner) parseComposeFile(
+// parseStack attempts to parse a stack of YAML files as a compose project.
+// Returns nil if a single file is not a compose file, and an error if the
+// stack looks like compose but cannot be loaded.
+func (s *Scanner) parseStack(
 	ctx context.Context,
-	path string,
+	stack composeStack,
 ) (*model.Project, error) {
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, err
+	files := make([]CachedFile, len(stack.files))
+	for i, path := range stack.files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = CachedFile{
+			Path:    path,
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+		}
 	}
 
-	checksum, err := fileChecksum(path)
-	if err != nil {
-		return nil, err
+	env := make([]CachedFile, 0, 1)
+	for _, path := range stack.envSources() {
+		file := CachedFile{Path: path}
+		if info, err := os.Stat(path); err == nil {
+			file.ModTime = info.ModTime()
+			file.Size = info.Size()
+		}
+		env = append(env, file)
 	}
 
-	s.mu.RLock()
-	cached, exists := s.cache[path]
-	s.mu.RUnlock()
-
-	if exists && cached.ModTime.Equal(info.ModTime()) &&
-		cached.CheckSum == checksum {
-		return cached.Project, nil
+	cached := s.cached(stack.key)
+	if cached.matches(stack) && cached.unchanged(files, env, false) {
+		return cached.Project, cached.err()
 	}
 
-	projectName := deriveProjectName(path)
-
-	oldStderr := os.Stderr
-	devNull, devNullErr := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
-	if devNullErr == nil {
-		os.Stderr = devNull
+	// Only hash once the cheap checks fail; touched but unchanged files
+	// keep their cached parse.
+	contents := make([][]byte, len(files))
+	for i := range files {
+		data, err := os.ReadFile(files[i].Path)
+		if err != nil {
+			return nil, err
+		}
+		contents[i] = data
+		files[i].CheckSum = checksumOf(data)
 	}
-
-	opts, err := cli.NewProjectOptions(
-		[]string{path},
-		cli.WithName(projectName),
-		cli.WithResolvedPaths(true),
-		cli.WithInterpolation(true),
-		cli.WithProfiles([]string{}),
-	)
-
-	os.Stderr = oldStderr
-	if devNull != nil {
-		devNull.Close()
+	for i := range env {
+		if data, err := os.ReadFile(env[i].Path); err == nil {
+			env[i].CheckSum = checksumOf(data)
+		}
 	}
 
-	if err != nil {
-		return nil, err
+	if cached.matches(stack) && cached.unchanged(files, env, true) {
+		s.remember(stack, cached.Project, cached.Model, cached.err(), files, env)
+		return cached.Project, cached.err()
 	}
 
-	os.Stderr = devNull
-	if devNullErr == nil {
-		devNull, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	// Plenty of other YAML lives in repositories; only files that look
+	// like compose files are loaded, so their failures are worth reporting.
+	if len(files) == 1 && !looksLikeCompose(stack.key, contents[0]) {
+		s.remember(stack, nil, nil, nil, files, env)
+		return nil, nil
 	}
 
-	composeProject, err := opts.LoadProject(ctx)
-
-	os.Stderr = oldStderr
-	if devNull != nil {
-		devNull.Close()
+	// The name is left to compose-go so it resolves exactly as docker
+	// compose would: COMPOSE_PROJECT_NAME, then the name key, then the
+	// directory name.
+	composeProject, err := loadProject(
+		ctx,
+		stack.files,
+		stack.envFilePaths(),
+		stack.profiles,
+	)
+	if err != nil && ctx.Err() == nil {
+		// Dependency cycles and references to undefined services fail the
+		// load; keep such a project visible and report them as warnings.
+		lenient, lenientErr := loadProject(
+			ctx,
+			stack.files,
+			stack.envFilePaths(),
+			stack.profiles,
+			cli.WithLoadOptions(func(o *loader.Options) {
+				o.SkipConsistencyCheck = true
+			}),
+		)
+		if lenientErr == nil && len(DependencyWarnings(lenient)) > 0 {
+			composeProject, err = lenient, nil
+		}
 	}
 	if err != nil {
+		if ctx.Err() == nil {
+			s.remember(stack, nil, nil, err, files, env)
+		}
 		return nil, err
 	}
 
-	if len(composeProject.Services) == 0 {
+	// Services behind inactive profiles still make this a compose project.
+	if len(composeProject.AllServices()) == 0 {
+		s.remember(stack, nil, nil, nil, files, env)
 		return nil, nil
 	}
 
-	services := make([]string, 0, len(composeProject.Services))
-	for _, svc := range composeProject.Services {
-		services = append(services, svc.Name)
+	declared := make([]string, 0)
+	for _, svc := range composeProject.AllServices() {
+		for _, profile := range svc.Profiles {
+			if !slices.Contains(declared, profile) {
+				declared = append(declared, profile)
+			}
+		}
 	}
+	sort.Strings(declared)
 
+	services := composeProject.ServiceNames()
+	if services == nil {
+		services = make([]string, 0)
+	}
+
+	primary := stack.files[0]
 	proj := &model.Project{
-		ID:              generateProjectID(path),
-		Name:            projectName,
-		Path:            filepath.Dir(path),
-		ComposeFile:     filepath.Base(path),
-		ComposeFilePath: path,
-		Environment:     detectEnvironment(path),
+		ID:              ProjectID(stack.key),
+		Name:            composeProject.Name,
+		Source:          model.SourceCompose,
+		Path:            filepath.Dir(primary),
+		ComposeFile:     filepath.Base(primary),
+		ComposeFilePath: primary,
+		ComposeFiles:    slices.Clone(stack.files),
+		AvailableFiles:  stack.available,
+		Profiles:        declared,
+		ActiveProfiles:  slices.Clone(stack.profiles),
+		EnvFiles:        slices.Clone(stack.envFiles),
+		Environment:     stackEnvironment(stack.files),
 		Status:          model.StatusUnknown,
 		Services:        services,
 		Containers:      make([]model.Container, 0),
@@ -223,33 +447,193 @@ func (s *Scanner) parseComposeFile(
 		UpdatedAt:       time.Now(),
 	}
 
-	s.mu.Lock()
-	s.cache[path] = &CachedProject{
+	s.remember(stack, proj, composeProject, nil, files, env)
+	return proj, nil
+}
+
+// remember caches the parse of a stack; proj and config are nil for
+// non-compose files and parseErr is set for compose files that failed to
+// load.
+func (s *Scanner) remember(
+	stack composeStack,
+	proj *model.Project,
+	config *types.Project,
+	parseErr error,
+	files, env []CachedFile,
+) {
+	entry := &CachedProject{
 		Project:  proj,
-		ModTime:  info.ModTime(),
-		CheckSum: checksum,
+		Model:    config,
+		Files:    files,
+		Profiles: stack.profiles,
+		EnvFiles: stack.envFiles,
+		Env:      env,
+	}
+	if config != nil {
+		entry.Warnings = DependencyWarnings(config)
+	}
+	if parseErr != nil {
+		entry.Error = parseErr.Error()
 	}
+
+	s.mu.Lock()
+	s.cache[stack.key] = entry
 	s.mu.Unlock()
+}
 
-	return proj, nil
+// matches reports whether the cached parse was of the same files as stack,
+// with the same profiles, env files and files available to choose from.
+func (c *CachedProject) matches(stack composeStack) bool {
+	if c == nil || len(c.Files) != len(stack.files) {
+		return false
+	}
+	for i, file := range c.Files {
+		if file.Path != stack.files[i] {
+			return false
+		}
+	}
+	if !slices.Equal(c.Profiles, stack.profiles) ||
+		!slices.Equal(c.EnvFiles, stack.envFiles) {
+		return false
+	}
+	return c.Project == nil ||
+		slices.Equal(c.Project.AvailableFiles, stack.available)
+}
+
+// unchanged compares files and env files with the cached ones by
+// modification time and size, or by checksum when byChecksum is set.
+func (c *CachedProject) unchanged(files, env []CachedFile, byChecksum bool) bool {
+	return sameFiles(c.Files, files, byChecksum) &&
+		sameFiles(c.Env, env, byChecksum)
+}
+
+func sameFiles(cached, files []CachedFile, byChecksum bool) bool {
+	if len(cached) != len(files) {
+		return false
+	}
+	for i, file := range files {
+		if cached[i].Path != file.Path {
+			return false
+		}
+		if byChecksum {
+			if cached[i].CheckSum != file.CheckSum {
+				return false
+			}
+			continue
+		}
+		if !cached[i].ModTime.Equal(file.ModTime) || cached[i].Size != file.Size {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CachedProject) err() error {
+	if c.Error == "" {
+		return nil
+	}
+	return errors.New(c.Error)
+}
+
+// looksLikeCompose reports whether a YAML file is meant as a compose file:
+// it is named like one or has a top-level services or include key.
+func looksLikeCompose(path string, data []byte) bool {
+	if strings.Contains(strings.ToLower(filepath.Base(path)), "compose") {
+		return true
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		for _, key := range []string{"services", "include"} {
+			if strings.HasPrefix(line, key+":") ||
+				strings.HasPrefix(line, `"`+key+`":`) ||
+				strings.HasPrefix(line, "'"+key+"':") {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GetPaths returns the configured scan paths.
 func (s *Scanner) GetPaths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.paths
 }
 
-// SetPaths updates the scan paths.
+// SetPaths updates the scan paths. The next scan walks the new paths.
 func (s *Scanner) SetPaths(paths []string) {
 	s.mu.Lock()
 	s.paths = paths
+	s.index = nil
 	s.mu.Unlock()
 }
 
-// ClearCache removes all cached projects.
+// ValidatePaths checks that every path names an existing directory,
+// expanding a leading ~/ like scans do. It returns the paths cleaned and
+// with duplicates dropped, as they should be passed to SetPaths.
+func ValidatePaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no paths given", ErrInvalidPath)
+	}
+
+	cleaned := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil, fmt.Errorf("%w: empty path", ErrInvalidPath)
+		}
+
+		expanded := expandPath(path)
+		if !filepath.IsAbs(expanded) {
+			return nil, fmt.Errorf("%w: %s is not absolute", ErrInvalidPath, path)
+		}
+
+		info, err := os.Stat(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPath, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%w: %s is not a directory", ErrInvalidPath, path)
+		}
+
+		if strings.HasPrefix(path, "~/") {
+			path = "~/" + filepath.Clean(path[2:])
+		} else {
+			path = filepath.Clean(path)
+		}
+		if !seen[path] {
+			seen[path] = true
+			cleaned = append(cleaned, path)
+		}
+	}
+
+	return cleaned, nil
+}
+
+// ComposeModel returns the compose model the project with the given ID
+// was last loaded from, or nil if no scanned project has that ID. The
+// model is shared and must not be modified.
+func (s *Scanner) ComposeModel(projectID string) *types.Project {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, cached := range s.cache {
+		if cached.Project != nil && cached.Project.ID == projectID {
+			return cached.Model
+		}
+	}
+	return nil
+}
+
+// ClearCache removes all cached projects and the file index, so the next
+// scan walks the scan paths and loads every compose file again.
 func (s *Scanner) ClearCache() {
 	s.mu.Lock()
 	s.cache = make(map[string]*CachedProject)
+	s.index = nil
 	s.mu.Unlock()
 }
 
@@ -308,51 +692,34 @@ func expandPath(path string) string {
 	return path
 }
 
-func deriveProjectName(composePath string) string {
-	dir := filepath.Dir(composePath)
-	name := filepath.Base(dir)
-	return sanitizeProjectName(name)
-}
-
-func sanitizeProjectName(name string) string {
-	name = strings.ToLower(name)
-	name = strings.ReplaceAll(name, ".", "-")
+// silenceStderr points stderr at the null device while compose files are
+// parsed, since compose-go prints warnings there. Concurrent scans are
+// serialized so stderr is not swapped from under another batch.
+func silenceStderr() func() {
+	stderrMu.Lock()
 
-	var result strings.Builder
-	for i, r := range name {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
-			result.WriteRune(r)
-		} else {
-			result.WriteRune('-')
-		}
-
-		if i == 0 && !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') {
-			result.Reset()
-			result.WriteRune('p')
-			result.WriteRune('-')
-		}
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return stderrMu.Unlock
 	}
 
-	sanitized := result.String()
-	sanitized = strings.Trim(sanitized, "-_")
+	oldStderr := os.Stderr
+	os.Stderr = devNull
 
-	if sanitized == "" {
-		return "project"
+	return func() {
+		os.Stderr = oldStderr
+		_ = devNull.Close()
+		stderrMu.Unlock()
 	}
-
-	return sanitized
 }
 
-func generateProjectID(composePath string) string {
+// ProjectID derives a project's ID from its compose file path.
+func ProjectID(composePath string) string {
 	hash := sha256.Sum256([]byte(composePath))
 	return hex.EncodeToString(hash[:8])
 }
 
-func fileChecksum(path string) (string, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
-	}
+func checksumOf(data []byte) string {
 	hash := sha256.Sum256(data)
-	return hex.EncodeToString(hash[:]), nil
+	return hex.EncodeToString(hash[:])
 }
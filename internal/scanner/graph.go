@@ -0,0 +1,163 @@
+/*
+AngelaMos | 2026
+graph.go
+*/
+
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+// DependencyEdges returns the dependencies between a project's services,
+// from depends_on, links, network_mode: service: and volumes_from. Compose
+// turns the last three into depends_on entries as well, so each pair of
+// services has a single edge of the most specific kind, carrying the
+// depends_on condition.
+func DependencyEdges(config *types.Project) []model.GraphEdge {
+	type pair struct{ from, to string }
+	edges := make([]model.GraphEdge, 0)
+	index := make(map[pair]int)
+
+	add := func(from, to string, kind model.EdgeKind) {
+		if i, ok := index[pair{from, to}]; ok {
+			if edges[i].Kind == model.EdgeDependsOn {
+				edges[i].Kind = kind
+			}
+			return
+		}
+		index[pair{from, to}] = len(edges)
+		edges = append(edges, model.GraphEdge{From: from, To: to, Kind: kind})
+	}
+
+	for _, name := range sortedKeys(config.Services) {
+		svc := config.Services[name]
+
+		for _, link := range svc.Links {
+			target, _, _ := strings.Cut(link, ":")
+			add(name, target, model.EdgeLink)
+		}
+		if target, ok := strings.CutPrefix(svc.NetworkMode, types.ServicePrefix); ok {
+			add(name, target, model.EdgeNetworkMode)
+		}
+		for _, source := range svc.VolumesFrom {
+			if strings.HasPrefix(source, types.ContainerPrefix) {
+				continue
+			}
+			source = strings.TrimPrefix(source, types.ServicePrefix)
+			target, _, _ := strings.Cut(source, ":")
+			add(name, target, model.EdgeVolumesFrom)
+		}
+		for _, target := range sortedKeys(svc.DependsOn) {
+			add(name, target, model.EdgeDependsOn)
+		}
+	}
+
+	for i := range edges {
+		edge := &edges[i]
+		if dependency, ok := config.Services[edge.From].DependsOn[edge.To]; ok {
+			edge.Condition = dependency.Condition
+			edge.Required = dependency.Required
+		}
+	}
+	return edges
+}
+
+// DependencyWarnings reports references to services a project does not
+// define and dependency cycles, both of which make docker compose up
+// fail. Services left out by inactive profiles are not undefined.
+func DependencyWarnings(config *types.Project) []string {
+	edges := DependencyEdges(config)
+	warnings := make([]string, 0)
+
+	for _, edge := range undefinedReferences(config, edges) {
+		warnings = append(warnings, fmt.Sprintf(
+			"service %s refers to undefined service %s (%s)",
+			edge.From,
+			edge.To,
+			edge.Kind,
+		))
+	}
+	for _, cycle := range dependencyCycles(config, edges) {
+		warnings = append(warnings,
+			"dependency cycle: "+strings.Join(cycle, " -> "))
+	}
+	return warnings
+}
+
+func undefinedReferences(config *types.Project, edges []model.GraphEdge) []model.GraphEdge {
+	undefined := make([]model.GraphEdge, 0)
+	for _, edge := range edges {
+		if _, ok := config.Services[edge.To]; ok {
+			continue
+		}
+		if _, ok := config.DisabledServices[edge.To]; ok {
+			continue
+		}
+		undefined = append(undefined, edge)
+	}
+	return undefined
+}
+
+// dependencyCycles finds the cycles among defined services, each as the
+// path that closes it, e.g. [a b a].
+func dependencyCycles(config *types.Project, edges []model.GraphEdge) [][]string {
+	dependencies := make(map[string][]string)
+	for _, edge := range edges {
+		if _, ok := config.Services[edge.To]; ok {
+			dependencies[edge.From] = append(dependencies[edge.From], edge.To)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	path := make([]string, 0)
+	cycles := make([][]string, 0)
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		path = append(path, name)
+		for _, next := range dependencies[name] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				start := len(path) - 1
+				for path[start] != next {
+					start--
+				}
+				cycle := append([]string{}, path[start:]...)
+				cycles = append(cycles, append(cycle, next))
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+
+	for _, name := range sortedKeys(config.Services) {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+/*
+AngelaMos | 2026
+options.go
+*/
+
+package scanner
+
+import (
+	"maps"
+	"slices"
+)
+
+// ProjectOptions are the user's choices for how a project is loaded.
+// Files names the compose files to stack, replacing the default stack
+// when all of them exist; Profiles are the compose profiles to activate;
+// EnvFiles names env files in the project directory to interpolate with
+// instead of .env.
+type ProjectOptions struct {
+	Files    []string
+	Profiles []string
+	EnvFiles []string
+}
+
+func (o ProjectOptions) empty() bool {
+	return len(o.Files) == 0 && len(o.Profiles) == 0 && len(o.EnvFiles) == 0
+}
+
+// SetFiles chooses the compose files, by name, that the project with the
+// given ID is loaded from. An empty list restores the default stack.
+func (s *Scanner) SetFiles(projectID string, names []string) {
+	s.updateOptions(projectID, func(opts *ProjectOptions) {
+		opts.Files = slices.Clone(names)
+	})
+}
+
+// SetProfiles chooses the compose profiles activated for the project with
+// the given ID. Services behind other profiles are left out of the project.
+func (s *Scanner) SetProfiles(projectID string, profiles []string) {
+	s.updateOptions(projectID, func(opts *ProjectOptions) {
+		opts.Profiles = slices.Clone(profiles)
+	})
+}
+
+// SetEnvFiles chooses the env files, by name, that the project with the
+// given ID is interpolated with. An empty list restores the default .env.
+func (s *Scanner) SetEnvFiles(projectID string, names []string) {
+	s.updateOptions(projectID, func(opts *ProjectOptions) {
+		opts.EnvFiles = slices.Clone(names)
+	})
+}
+
+// SetAllOptions replaces the options of every project.
+func (s *Scanner) SetAllOptions(all map[string]ProjectOptions) {
+	options := make(map[string]ProjectOptions, len(all))
+	for id, opts := range all {
+		if !opts.empty() {
+			options[id] = opts
+		}
+	}
+
+	s.mu.Lock()
+	s.options = options
+	s.mu.Unlock()
+}
+
+// updateOptions changes one project's options. Scans read the map without
+// holding the lock, so it is replaced rather than changed in place.
+func (s *Scanner) updateOptions(projectID string, update func(*ProjectOptions)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	options := maps.Clone(s.options)
+	if options == nil {
+		options = make(map[string]ProjectOptions)
+	}
+
+	opts := options[projectID]
+	update(&opts)
+	if opts.empty() {
+		delete(options, projectID)
+	} else {
+		options[projectID] = opts
+	}
+	s.options = options
+}
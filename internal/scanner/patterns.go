@@ -32,9 +32,9 @@ var productionPatterns = []string{
 	"master",
 }
 
-// detectEnvironment determines if a compose file is for dev or prod
+// DetectEnvironment determines if a compose file is for dev or prod
 // based on filename patterns.
-func detectEnvironment(composePath string) model.Environment {
+func DetectEnvironment(composePath string) model.Environment {
 	filename := strings.ToLower(filepath.Base(composePath))
 	filenameWithoutExt := strings.TrimSuffix(filename, filepath.Ext(filename))
 
@@ -0,0 +1,203 @@
+/*
+AngelaMos | 2026
+stacks.go
+*/
+
+package scanner
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/carterperez-dev/holophyly/internal/model"
+)
+
+// baseFilePrecedence is the order docker compose picks a default file in,
+// when a directory has more than one.
+var baseFilePrecedence = []string{
+	"compose.yaml",
+	"compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.yml",
+}
+
+// composeStack is a set of compose files loaded together as one project,
+// like repeated -f flags. Key is the directory's base compose file, or the
+// only file for a single-file project, and identifies the project however
+// its files are chosen.
+type composeStack struct {
+	key   string
+	files []string
+	// available lists the file names in the directory the stack can be
+	// built from; it is nil for files that are not part of a group.
+	available []string
+	profiles  []string
+	envFiles  []string
+}
+
+// changed reports whether any file of the stack is in dirty.
+func (c composeStack) changed(dirty map[string]bool) bool {
+	for _, file := range c.files {
+		if dirty[file] {
+			return true
+		}
+	}
+	return false
+}
+
+// groupStacks groups YAML files into stacks. In a directory with a base
+// compose file, the other compose-named files become optional layers of
+// that project instead of projects of their own; by default the base is
+// loaded with its override file, as docker compose does. A stack chosen
+// with SetFiles replaces the default if all its files still exist. Any
+// other file is a single-file stack. Each stack carries the profiles and
+// env files chosen for its project.
+func (s *Scanner) groupStacks(files []string) []composeStack {
+	byDir := make(map[string][]string)
+	dirs := make([]string, 0)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], file)
+	}
+
+	s.mu.RLock()
+	chosen := s.options
+	s.mu.RUnlock()
+
+	stacks := make([]composeStack, 0, len(files))
+	for _, dir := range dirs {
+		for _, stack := range groupDir(dir, byDir[dir], chosen) {
+			opts := chosen[ProjectID(stack.key)]
+			stack.profiles = opts.Profiles
+			stack.envFiles = opts.EnvFiles
+			stacks = append(stacks, stack)
+		}
+	}
+	return stacks
+}
+
+func groupDir(
+	dir string,
+	files []string,
+	chosen map[string]ProjectOptions,
+) []composeStack {
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[filepath.Base(file)] = true
+	}
+
+	base := ""
+	for _, name := range baseFilePrecedence {
+		if names[name] {
+			base = name
+			break
+		}
+	}
+
+	stacks := make([]composeStack, 0, len(files))
+	if base == "" {
+		for _, file := range files {
+			stacks = append(stacks, composeStack{key: file, files: []string{file}})
+		}
+		return stacks
+	}
+
+	layers := make([]string, 0)
+	for _, file := range files {
+		name := filepath.Base(file)
+		info := ClassifyComposeFile(name)
+		switch {
+		case name == base:
+		case info.IsBase, !strings.Contains(strings.ToLower(name), "compose"):
+			// Another default file, which docker compose would ignore here,
+			// or unrelated YAML: both stay projects of their own.
+			stacks = append(stacks, composeStack{key: file, files: []string{file}})
+		default:
+			layers = append(layers, name)
+		}
+	}
+	sort.Strings(layers)
+
+	available := append([]string{base}, layers...)
+	key := filepath.Join(dir, base)
+
+	selected := chosen[ProjectID(key)].Files
+	if len(selected) == 0 || !isSubset(selected, available) {
+		selected = defaultStack(base, layers)
+	}
+
+	stackFiles := make([]string, len(selected))
+	for i, name := range selected {
+		stackFiles[i] = filepath.Join(dir, name)
+	}
+
+	return append(stacks, composeStack{
+		key:       key,
+		files:     stackFiles,
+		available: available,
+	})
+}
+
+// defaultStack is the base file and its override, e.g. compose.yml with
+// compose.override.yml.
+func defaultStack(base string, layers []string) []string {
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	for _, name := range layers {
+		if !ClassifyComposeFile(name).IsOverride {
+			continue
+		}
+		if strings.TrimSuffix(name, filepath.Ext(name)) == stem+".override" {
+			return []string{base, name}
+		}
+	}
+	return []string{base}
+}
+
+func isSubset(names, available []string) bool {
+	for _, name := range names {
+		if !slices.Contains(available, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// envFilePaths resolves the chosen env files against the project
+// directory.
+func (c composeStack) envFilePaths() []string {
+	paths := make([]string, len(c.envFiles))
+	for i, name := range c.envFiles {
+		paths[i] = filepath.Join(filepath.Dir(c.files[0]), name)
+	}
+	return paths
+}
+
+// envSources lists the env files loading the stack reads: the chosen
+// ones, or else the project directory's .env, as with docker compose.
+func (c composeStack) envSources() []string {
+	if len(c.envFiles) > 0 {
+		return c.envFilePaths()
+	}
+	return []string{filepath.Join(filepath.Dir(c.files[0]), ".env")}
+}
+
+// stackEnvironment detects the environment from the base file, letting a
+// layer such as compose.prod.yml say otherwise.
+func stackEnvironment(files []string) model.Environment {
+	env := DetectEnvironment(files[0])
+	for _, file := range files[1:] {
+		info := ClassifyComposeFile(filepath.Base(file))
+		if info.IsOverride {
+			continue
+		}
+		if info.Environment != model.EnvUnknown {
+			env = info.Environment
+		}
+	}
+	return env
+}
@@ -0,0 +1,381 @@
+/*
+AngelaMos | 2026
+watcher.go
+*/
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce lets bursts of changes, like a git checkout, settle into a
+// single rescan.
+const watchDebounce = 500 * time.Millisecond
+
+// Watch keeps the file index current from filesystem events until ctx is
+// cancelled, calling onChange once a burst of compose or env file changes
+// has settled. Scans then use the index instead of walking the scan paths.
+// If the system runs out of watches, watching is abandoned and scans walk
+// the paths again, so periodic scans should keep running either way.
+func (s *Scanner) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.watcher = watcher
+	s.index = nil
+	s.mu.Unlock()
+
+	go s.watchLoop(ctx, watcher, onChange)
+	return nil
+}
+
+// Watching reports whether filesystem watching is active.
+func (s *Scanner) Watching() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.watcher != nil
+}
+
+func (s *Scanner) watchLoop(
+	ctx context.Context,
+	watcher *fsnotify.Watcher,
+	onChange func(),
+) {
+	logger := slog.Default()
+	settle := time.NewTimer(watchDebounce)
+	settle.Stop()
+	defer settle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.stopWatching(watcher, "")
+			return
+
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if s.handleEvent(ev) {
+				settle.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were lost; walk again on the next scan.
+				s.invalidateIndex()
+				settle.Reset(watchDebounce)
+				continue
+			}
+			logger.Warn("file watcher error", "error", err)
+
+		case <-settle.C:
+			onChange()
+		}
+	}
+}
+
+// handleEvent applies a filesystem event to the index and reports whether
+// it may have changed the set of compose projects or their parses.
+func (s *Scanner) handleEvent(ev fsnotify.Event) bool {
+	path := ev.Name
+
+	switch {
+	case ev.Has(fsnotify.Create):
+		info, err := os.Stat(path)
+		if err != nil {
+			return false
+		}
+		if info.IsDir() {
+			if s.shouldExcludeDir(info.Name()) {
+				return false
+			}
+			return s.indexDir(path)
+		}
+		envChanged := s.markEnvFile(path)
+		if !isYAMLFile(path) {
+			return envChanged
+		}
+		s.markFile(path)
+		return true
+
+	case ev.Has(fsnotify.Write):
+		envChanged := s.markEnvFile(path)
+		if !isYAMLFile(path) {
+			return envChanged
+		}
+		s.markFile(path)
+		return true
+
+	case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+		envChanged := s.markEnvFile(path)
+		return s.forget(path) || envChanged
+	}
+
+	return false
+}
+
+// indexDir watches a new directory tree and indexes its YAML files.
+func (s *Scanner) indexDir(root string) bool {
+	found := false
+
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && s.shouldExcludeDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			if !s.addWatch(path) {
+				return filepath.SkipAll
+			}
+			return nil
+		}
+		if isYAMLFile(path) {
+			s.markFile(path)
+			found = true
+		}
+		return nil
+	})
+
+	return found
+}
+
+// markFile adds a file to the index and flags it for reparsing. While the
+// index is being rebuilt, the file is only flagged; rebuildIndex adds it.
+func (s *Scanner) markFile(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watcher == nil {
+		return
+	}
+	if s.index == nil {
+		s.pendLocked(path)
+		return
+	}
+	s.index[path] = true
+	s.dirty[path] = true
+}
+
+// pendLocked records a change seen while the index is being rebuilt, for
+// rebuildIndex to apply. Callers hold the lock.
+func (s *Scanner) pendLocked(path string) {
+	if s.dirty == nil {
+		s.dirty = make(map[string]bool)
+	}
+	s.dirty[path] = true
+}
+
+// markEnvFile flags for reparsing every stack whose last parse read the
+// env file at path, and reports whether there was any.
+func (s *Scanner) markEnvFile(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index == nil {
+		return false
+	}
+
+	marked := false
+	for _, cached := range s.cache {
+		for _, env := range cached.Env {
+			if env.Path != path {
+				continue
+			}
+			for _, file := range cached.Files {
+				s.dirty[file.Path] = true
+			}
+			marked = true
+			break
+		}
+	}
+	return marked
+}
+
+// forget drops a removed file, or every file under a removed directory,
+// from the index and cache.
+func (s *Scanner) forget(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watcher == nil {
+		return false
+	}
+	if s.index == nil {
+		s.pendLocked(path)
+		return true
+	}
+
+	removed := false
+	prefix := path + string(filepath.Separator)
+	for file := range s.index {
+		if file == path || strings.HasPrefix(file, prefix) {
+			delete(s.index, file)
+			delete(s.dirty, file)
+			delete(s.cache, file)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// addWatch watches a directory, giving up on watching altogether when the
+// system limit on watches is reached.
+func (s *Scanner) addWatch(dir string) bool {
+	s.mu.RLock()
+	watcher := s.watcher
+	s.mu.RUnlock()
+
+	if watcher == nil {
+		return false
+	}
+
+	err := watcher.Add(dir)
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) {
+		s.stopWatching(watcher,
+			"file watch limit reached - falling back to periodic scans "+
+				"(raise fs.inotify.max_user_watches to watch again)")
+		return false
+	}
+
+	slog.Default().Debug("watching directory", "path", dir, "error", err)
+	return true
+}
+
+// stopWatching closes the watcher and returns scans to walking the paths.
+func (s *Scanner) stopWatching(watcher *fsnotify.Watcher, reason string) {
+	s.mu.Lock()
+	if s.watcher != watcher {
+		s.mu.Unlock()
+		return
+	}
+	s.watcher = nil
+	s.index = nil
+	s.dirty = nil
+	s.mu.Unlock()
+
+	_ = watcher.Close()
+
+	if reason != "" {
+		slog.Default().Warn(reason)
+	}
+}
+
+func (s *Scanner) invalidateIndex() {
+	s.mu.Lock()
+	s.index = nil
+	s.mu.Unlock()
+}
+
+// indexedFiles returns the indexed YAML files and takes the set changed
+// since the last scan; changes arriving during the scan start a new set.
+// indexed is false when a walk is needed instead.
+func (s *Scanner) indexedFiles() (files []string, dirty map[string]bool, indexed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watcher == nil || s.index == nil {
+		return nil, nil, false
+	}
+
+	files = make([]string, 0, len(s.index))
+	for file := range s.index {
+		files = append(files, file)
+	}
+
+	dirty = s.dirty
+	s.dirty = make(map[string]bool)
+
+	return files, dirty, true
+}
+
+// rebuildIndex replaces the index after a walk, which watched every
+// walked directory, and applies the changes seen while it ran. It does
+// nothing when not watching.
+func (s *Scanner) rebuildIndex(files, dirs []string) {
+	s.mu.RLock()
+	watcher := s.watcher
+	s.mu.RUnlock()
+
+	if watcher == nil {
+		return
+	}
+
+	wanted := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		wanted[dir] = true
+	}
+
+	// Directories outside the current paths, e.g. after SetPaths.
+	for _, dir := range watcher.WatchList() {
+		if !wanted[dir] {
+			_ = watcher.Remove(dir)
+		}
+	}
+
+	index := make(map[string]bool, len(files))
+	for _, file := range files {
+		index[file] = true
+	}
+
+	s.mu.Lock()
+	if s.watcher != nil {
+		// Files changed during the walk stay dirty for the next scan,
+		// which the change itself triggers.
+		for path := range s.dirty {
+			if _, err := os.Stat(path); err == nil {
+				if isYAMLFile(path) {
+					index[path] = true
+				}
+				continue
+			}
+			prefix := path + string(filepath.Separator)
+			for file := range index {
+				if file == path || strings.HasPrefix(file, prefix) {
+					delete(index, file)
+				}
+			}
+		}
+		s.index = index
+		if s.dirty == nil {
+			s.dirty = make(map[string]bool)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// restoreDirty puts back changed files an interrupted scan did not get to.
+func (s *Scanner) restoreDirty(dirty map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dirty == nil {
+		return
+	}
+	for file := range dirty {
+		s.dirty[file] = true
+	}
+}
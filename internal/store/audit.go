@@ -0,0 +1,167 @@
+/*
+AngelaMos | 2026
+audit.go
+*/
+
+package store
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type AuditRecord struct {
+	ID         int64
+	Time       time.Time
+	ActorID    string
+	Actor      string
+	Action     string
+	TargetType string
+	Target     string
+	TargetName string
+	Params     string
+	Result     string
+	Output     string
+	Error      string
+	DurationMS int64
+	RequestID  string
+}
+
+// AuditFilter narrows an audit query. Zero values match everything.
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	Target     string
+	Result     string
+	Since      time.Time
+	Until      time.Time
+	Limit      int
+	Offset     int
+}
+
+const auditColumns = `id, ts, actor_id, actor, action, target_type, target,
+	target_name, params, result, output, error, duration_ms, request_id`
+
+// InsertAudit appends a record and sets its ID.
+func (s *Store) InsertAudit(record *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.db.Exec(`
+		INSERT INTO audit_log (
+			ts, actor_id, actor, action, target_type, target, target_name,
+			params, result, output, error, duration_ms, request_id
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.Time.UnixMilli(),
+		record.ActorID,
+		record.Actor,
+		record.Action,
+		record.TargetType,
+		record.Target,
+		record.TargetName,
+		record.Params,
+		record.Result,
+		record.Output,
+		record.Error,
+		record.DurationMS,
+		record.RequestID,
+	)
+	if err != nil {
+		return err
+	}
+
+	record.ID, err = result.LastInsertId()
+	return err
+}
+
+// QueryAudit returns matching records newest first, along with the total
+// number of matches ignoring limit and offset.
+func (s *Store) QueryAudit(filter AuditFilter) ([]*AuditRecord, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var conditions []string
+	var args []any
+
+	addEquals := func(column, value string) {
+		if value != "" {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+	addEquals("actor", filter.Actor)
+	addEquals("action", filter.Action)
+	addEquals("target_type", filter.TargetType)
+	addEquals("target", filter.Target)
+	addEquals("result", filter.Result)
+
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "ts >= ?")
+		args = append(args, filter.Since.UnixMilli())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "ts <= ?")
+		args = append(args, filter.Until.UnixMilli())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM audit_log"+where,
+		args...,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(
+		"SELECT "+auditColumns+" FROM audit_log"+where+
+			" ORDER BY ts DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, filter.Limit, filter.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	records := make([]*AuditRecord, 0)
+	for rows.Next() {
+		var record AuditRecord
+		var ts int64
+		var params, output, errText sql.NullString
+
+		if err := rows.Scan(
+			&record.ID,
+			&ts,
+			&record.ActorID,
+			&record.Actor,
+			&record.Action,
+			&record.TargetType,
+			&record.Target,
+			&record.TargetName,
+			&params,
+			&record.Result,
+			&output,
+			&errText,
+			&record.DurationMS,
+			&record.RequestID,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		record.Time = time.UnixMilli(ts)
+		record.Params = params.String
+		record.Output = output.String
+		record.Error = errText.String
+		records = append(records, &record)
+	}
+
+	return records, total, rows.Err()
+}
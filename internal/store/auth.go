@@ -0,0 +1,263 @@
+/*
+AngelaMos | 2026
+auth.go
+*/
+
+package store
+
+import (
+	"database/sql"
+	"time"
+)
+
+type APIToken struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Hash       string
+	Role       string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+type Session struct {
+	TokenID   string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+const tokenColumns = `id, name, prefix, token_hash, role, created_at,
+	expires_at, last_used_at, revoked_at`
+
+func (s *Store) CreateToken(token *APIToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`
+		INSERT INTO api_tokens (
+			id, name, prefix, token_hash, role, created_at, expires_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		token.ID,
+		token.Name,
+		token.Prefix,
+		token.Hash,
+		token.Role,
+		token.CreatedAt.Unix(),
+		nullUnix(token.ExpiresAt),
+	)
+	return err
+}
+
+// GetTokenByHash returns the token with the given hash, or nil if none.
+func (s *Store) GetTokenByHash(hash string) (*APIToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row := s.db.QueryRow(
+		"SELECT "+tokenColumns+" FROM api_tokens WHERE token_hash = ?",
+		hash,
+	)
+
+	token, err := scanToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+// GetToken returns the token with the given ID, or nil if none.
+func (s *Store) GetToken(id string) (*APIToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row := s.db.QueryRow(
+		"SELECT "+tokenColumns+" FROM api_tokens WHERE id = ?",
+		id,
+	)
+
+	token, err := scanToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (s *Store) ListTokens() ([]*APIToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(
+		"SELECT " + tokenColumns + " FROM api_tokens ORDER BY created_at",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]*APIToken, 0)
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// CountActiveTokens returns the number of tokens that are not revoked.
+func (s *Store) CountActiveTokens() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM api_tokens WHERE revoked_at IS NULL",
+	).Scan(&count)
+	return count, err
+}
+
+// RevokeToken marks a token revoked and drops its sessions.
+// Returns false if no active token has that ID.
+func (s *Store) RevokeToken(id string, at time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.db.Exec(
+		"UPDATE api_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL",
+		at.Unix(),
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	_, err = s.db.Exec("DELETE FROM sessions WHERE token_id = ?", id)
+	return true, err
+}
+
+func (s *Store) TouchToken(id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(
+		"UPDATE api_tokens SET last_used_at = ? WHERE id = ?",
+		at.Unix(),
+		id,
+	)
+	return err
+}
+
+func (s *Store) CreateSession(hash string, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`
+		INSERT INTO sessions (session_hash, token_id, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+	`,
+		hash,
+		session.TokenID,
+		session.CreatedAt.Unix(),
+		session.ExpiresAt.Unix(),
+	)
+	return err
+}
+
+// GetSession returns the session with the given hash, or nil if none.
+func (s *Store) GetSession(hash string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var session Session
+	var createdAt, expiresAt int64
+
+	err := s.db.QueryRow(
+		"SELECT token_id, created_at, expires_at FROM sessions WHERE session_hash = ?",
+		hash,
+	).Scan(&session.TokenID, &createdAt, &expiresAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.CreatedAt = time.Unix(createdAt, 0)
+	session.ExpiresAt = time.Unix(expiresAt, 0)
+
+	return &session, nil
+}
+
+func (s *Store) DeleteSession(hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec("DELETE FROM sessions WHERE session_hash = ?", hash)
+	return err
+}
+
+func (s *Store) DeleteExpiredSessions(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec("DELETE FROM sessions WHERE expires_at < ?", now.Unix())
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanToken(row rowScanner) (*APIToken, error) {
+	var token APIToken
+	var createdAt int64
+	var expiresAt, lastUsedAt, revokedAt sql.NullInt64
+
+	if err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&token.Prefix,
+		&token.Hash,
+		&token.Role,
+		&createdAt,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt = time.Unix(createdAt, 0)
+	token.ExpiresAt = timeFromNull(expiresAt)
+	token.LastUsedAt = timeFromNull(lastUsedAt)
+	token.RevokedAt = timeFromNull(revokedAt)
+
+	return &token, nil
+}
+
+func nullUnix(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+func timeFromNull(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := time.Unix(v.Int64, 0)
+	return &t
+}
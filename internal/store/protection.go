@@ -0,0 +1,101 @@
+/*
+AngelaMos | 2026
+protection.go
+*/
+
+package store
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ProjectProtection is a user decision about a project's protection.
+// Protected false is an explicit override that suppresses automatic
+// detection.
+type ProjectProtection struct {
+	ProjectID string
+	Protected bool
+	Reason    string
+	Note      string
+	SetBy     string
+	SetAt     time.Time
+}
+
+func (s *Store) GetAllProtections() (map[string]*ProjectProtection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`
+		SELECT project_id, protected, reason, note, set_by, set_at
+		FROM project_protection
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	protections := make(map[string]*ProjectProtection)
+	for rows.Next() {
+		var p ProjectProtection
+		var protected int
+		var reason, note, setBy sql.NullString
+		var setAt int64
+
+		if err := rows.Scan(
+			&p.ProjectID,
+			&protected,
+			&reason,
+			&note,
+			&setBy,
+			&setAt,
+		); err != nil {
+			return nil, err
+		}
+
+		p.Protected = protected == 1
+		p.Reason = reason.String
+		p.Note = note.String
+		p.SetBy = setBy.String
+		p.SetAt = time.Unix(setAt, 0)
+		protections[p.ProjectID] = &p
+	}
+
+	return protections, rows.Err()
+}
+
+func (s *Store) SetProtection(p *ProjectProtection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	protected := 0
+	if p.Protected {
+		protected = 1
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO project_protection (
+			project_id, protected, reason, note, set_by, set_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(project_id) DO UPDATE SET
+			protected = excluded.protected,
+			reason = excluded.reason,
+			note = excluded.note,
+			set_by = excluded.set_by,
+			set_at = excluded.set_at
+	`, p.ProjectID, protected, p.Reason, p.Note, p.SetBy, p.SetAt.Unix())
+
+	return err
+}
+
+func (s *Store) DeleteProtection(projectID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(
+		"DELETE FROM project_protection WHERE project_id = ?",
+		projectID,
+	)
+	return err
+}
@@ -0,0 +1,218 @@
+/*
+AngelaMos | 2026
+stats.go
+*/
+
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stats resolutions in seconds. Raw samples are rolled up into minute and
+// hour buckets so long ranges stay cheap to store and query.
+const (
+	ResolutionRaw    = 0
+	ResolutionMinute = 60
+	ResolutionHour   = 3600
+)
+
+type StatsSample struct {
+	Timestamp     time.Time
+	Host          string
+	ProjectID     string
+	ContainerID   string
+	ContainerName string
+	ServiceName   string
+	CPUPercent    float64
+	MemoryUsage   uint64
+	MemoryLimit   uint64
+	NetworkRx     uint64
+	NetworkTx     uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	PIDs          uint64
+}
+
+// InsertStatsSamples stores raw stats samples in a single transaction.
+func (s *Store) InsertStatsSamples(samples []StatsSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`
+		INSERT OR REPLACE INTO container_stats (
+			resolution, ts, host, project_id, container_id, container_name,
+			service_name, cpu_percent, memory_usage, memory_limit,
+			network_rx, network_tx, block_read, block_write, pids
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, sample := range samples {
+		_, err := stmt.Exec(
+			ResolutionRaw,
+			sample.Timestamp.Unix(),
+			sample.Host,
+			sample.ProjectID,
+			sample.ContainerID,
+			sample.ContainerName,
+			sample.ServiceName,
+			sample.CPUPercent,
+			int64(sample.MemoryUsage),
+			int64(sample.MemoryLimit),
+			int64(sample.NetworkRx),
+			int64(sample.NetworkTx),
+			int64(sample.BlockRead),
+			int64(sample.BlockWrite),
+			int64(sample.PIDs),
+		)
+		if err != nil {
+			return fmt.Errorf("inserting stats sample: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RollupStats aggregates samples of one resolution into buckets of a coarser
+// one for the window [since, before). Gauges are averaged and cumulative
+// counters keep their maximum. Re-running over the same window is
+// idempotent, so overlapping windows are safe.
+func (s *Store) RollupStats(from, to int, since, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`
+		INSERT INTO container_stats (
+			resolution, ts, host, project_id, container_id, container_name,
+			service_name, cpu_percent, memory_usage, memory_limit,
+			network_rx, network_tx, block_read, block_write, pids
+		)
+		SELECT ?, (ts / ?) * ?, MAX(host), MAX(project_id), container_id,
+			MAX(container_name), MAX(service_name), AVG(cpu_percent),
+			CAST(AVG(memory_usage) AS INTEGER), MAX(memory_limit),
+			MAX(network_rx), MAX(network_tx), MAX(block_read),
+			MAX(block_write), MAX(pids)
+		FROM container_stats
+		WHERE resolution = ? AND ts >= ? AND ts < ?
+		GROUP BY container_id, ts / ?
+		ON CONFLICT (resolution, container_id, ts) DO UPDATE SET
+			cpu_percent = excluded.cpu_percent,
+			memory_usage = excluded.memory_usage,
+			memory_limit = excluded.memory_limit,
+			network_rx = excluded.network_rx,
+			network_tx = excluded.network_tx,
+			block_read = excluded.block_read,
+			block_write = excluded.block_write,
+			pids = excluded.pids
+	`,
+		to, to, to,
+		from, since.Unix(), before.Unix(),
+		to,
+	)
+	if err != nil {
+		return fmt.Errorf("rolling up stats to %ds: %w", to, err)
+	}
+
+	return nil
+}
+
+// PruneStats deletes samples of a resolution older than the cutoff.
+func (s *Store) PruneStats(resolution int, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(
+		"DELETE FROM container_stats WHERE resolution = ? AND ts < ?",
+		resolution,
+		before.Unix(),
+	)
+	return err
+}
+
+// QueryStats returns samples for a project bucketed into step-second
+// intervals, read from the given source resolution.
+func (s *Store) QueryStats(
+	projectID string,
+	resolution int,
+	from, to time.Time,
+	step int,
+) ([]StatsSample, error) {
+	if step <= 0 {
+		step = 1
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`
+		SELECT (ts / ?) * ?, MAX(host), container_id, MAX(container_name),
+			MAX(service_name), AVG(cpu_percent),
+			CAST(AVG(memory_usage) AS INTEGER), MAX(memory_limit),
+			MAX(network_rx), MAX(network_tx), MAX(block_read),
+			MAX(block_write), MAX(pids)
+		FROM container_stats
+		WHERE project_id = ? AND resolution = ? AND ts >= ? AND ts <= ?
+		GROUP BY container_id, ts / ?
+		ORDER BY 1
+	`,
+		step, step,
+		projectID, resolution, from.Unix(), to.Unix(),
+		step,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := make([]StatsSample, 0)
+	for rows.Next() {
+		var sample StatsSample
+		var ts, memUsage, memLimit, rx, tx, blkRead, blkWrite, pids int64
+
+		if err := rows.Scan(
+			&ts,
+			&sample.Host,
+			&sample.ContainerID,
+			&sample.ContainerName,
+			&sample.ServiceName,
+			&sample.CPUPercent,
+			&memUsage,
+			&memLimit,
+			&rx,
+			&tx,
+			&blkRead,
+			&blkWrite,
+			&pids,
+		); err != nil {
+			return nil, err
+		}
+
+		sample.Timestamp = time.Unix(ts, 0)
+		sample.ProjectID = projectID
+		sample.MemoryUsage = uint64(memUsage)
+		sample.MemoryLimit = uint64(memLimit)
+		sample.NetworkRx = uint64(rx)
+		sample.NetworkTx = uint64(tx)
+		sample.BlockRead = uint64(blkRead)
+		sample.BlockWrite = uint64(blkWrite)
+		sample.PIDs = uint64(pids)
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
@@ -7,6 +7,7 @@ package store
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,9 +22,12 @@ type Store struct {
 }
 
 type ProjectPreference struct {
-	ProjectID   string
-	DisplayName string
-	Hidden      bool
+	ProjectID    string
+	DisplayName  string
+	Hidden       bool
+	ComposeFiles []string
+	Profiles     []string
+	EnvFiles     []string
 }
 
 func New(dataDir string) (*Store, error) {
@@ -58,24 +62,151 @@ func (s *Store) migrate() error {
 			display_name TEXT,
 			hidden INTEGER DEFAULT 0
 		);
+
+		CREATE TABLE IF NOT EXISTS container_stats (
+			resolution INTEGER NOT NULL,
+			ts INTEGER NOT NULL,
+			host TEXT NOT NULL,
+			project_id TEXT NOT NULL,
+			container_id TEXT NOT NULL,
+			container_name TEXT,
+			service_name TEXT,
+			cpu_percent REAL,
+			memory_usage INTEGER,
+			memory_limit INTEGER,
+			network_rx INTEGER,
+			network_tx INTEGER,
+			block_read INTEGER,
+			block_write INTEGER,
+			pids INTEGER,
+			PRIMARY KEY (resolution, container_id, ts)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_container_stats_project
+			ON container_stats (project_id, resolution, ts);
+
+		CREATE TABLE IF NOT EXISTS api_tokens (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			prefix TEXT NOT NULL,
+			token_hash TEXT NOT NULL UNIQUE,
+			created_at INTEGER NOT NULL,
+			expires_at INTEGER,
+			last_used_at INTEGER,
+			revoked_at INTEGER
+		);
+
+		CREATE TABLE IF NOT EXISTS sessions (
+			session_hash TEXT PRIMARY KEY,
+			token_id TEXT NOT NULL,
+			created_at INTEGER NOT NULL,
+			expires_at INTEGER NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			ts INTEGER NOT NULL,
+			actor_id TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			action TEXT NOT NULL,
+			target_type TEXT NOT NULL DEFAULT '',
+			target TEXT NOT NULL DEFAULT '',
+			target_name TEXT NOT NULL DEFAULT '',
+			params TEXT,
+			result TEXT NOT NULL,
+			output TEXT,
+			error TEXT,
+			duration_ms INTEGER NOT NULL DEFAULT 0,
+			request_id TEXT NOT NULL DEFAULT ''
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_audit_log_ts ON audit_log (ts);
+
+		CREATE TABLE IF NOT EXISTS project_protection (
+			project_id TEXT PRIMARY KEY,
+			protected INTEGER NOT NULL,
+			reason TEXT,
+			note TEXT,
+			set_by TEXT,
+			set_at INTEGER NOT NULL
+		);
 	`
 
-	_, err := s.db.Exec(schema)
-	return err
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// Columns added after a table was first released. Tokens created
+	// before roles existed keep full access.
+	return s.addColumns([]columnMigration{
+		{"api_tokens", "role", "TEXT NOT NULL DEFAULT 'admin'"},
+		{"project_preferences", "compose_files", "TEXT"},
+		{"project_preferences", "profiles", "TEXT"},
+		{"project_preferences", "env_files", "TEXT"},
+	})
+}
+
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+}
+
+// addColumns adds any missing columns. SQLite has no
+// ADD COLUMN IF NOT EXISTS, so existing columns are read from table_info.
+func (s *Store) addColumns(migrations []columnMigration) error {
+	for _, m := range migrations {
+		rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", m.table))
+		if err != nil {
+			return err
+		}
+
+		exists := false
+		for rows.Next() {
+			var (
+				cid       int
+				name      string
+				colType   string
+				notNull   int
+				dfltValue sql.NullString
+				pk        int
+			)
+			if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+				rows.Close()
+				return err
+			}
+			if name == m.column {
+				exists = true
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if exists {
+			continue
+		}
+
+		if _, err := s.db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN %s %s",
+			m.table, m.column, m.definition,
+		)); err != nil {
+			return fmt.Errorf("adding %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	return nil
 }
 
 func (s *Store) GetPreference(projectID string) (*ProjectPreference, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var pref ProjectPreference
-	var displayName sql.NullString
-	var hidden int
-
-	err := s.db.QueryRow(
-		"SELECT project_id, display_name, hidden FROM project_preferences WHERE project_id = ?",
+	pref, err := scanPreference(s.db.QueryRow(
+		"SELECT project_id, display_name, hidden, compose_files, profiles, env_files FROM project_preferences WHERE project_id = ?",
 		projectID,
-	).Scan(&pref.ProjectID, &displayName, &hidden)
+	))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -84,17 +215,16 @@ func (s *Store) GetPreference(projectID string) (*ProjectPreference, error) {
 		return nil, err
 	}
 
-	pref.DisplayName = displayName.String
-	pref.Hidden = hidden == 1
-
-	return &pref, nil
+	return pref, nil
 }
 
 func (s *Store) GetAllPreferences() (map[string]*ProjectPreference, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rows, err := s.db.Query("SELECT project_id, display_name, hidden FROM project_preferences")
+	rows, err := s.db.Query(
+		"SELECT project_id, display_name, hidden, compose_files, profiles, env_files FROM project_preferences",
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -102,22 +232,53 @@ func (s *Store) GetAllPreferences() (map[string]*ProjectPreference, error) {
 
 	prefs := make(map[string]*ProjectPreference)
 	for rows.Next() {
-		var pref ProjectPreference
-		var displayName sql.NullString
-		var hidden int
-
-		if err := rows.Scan(&pref.ProjectID, &displayName, &hidden); err != nil {
+		pref, err := scanPreference(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		pref.DisplayName = displayName.String
-		pref.Hidden = hidden == 1
-		prefs[pref.ProjectID] = &pref
+		prefs[pref.ProjectID] = pref
 	}
 
 	return prefs, rows.Err()
 }
 
+func scanPreference(row rowScanner) (*ProjectPreference, error) {
+	var pref ProjectPreference
+	var displayName, composeFiles, profiles, envFiles sql.NullString
+	var hidden int
+
+	if err := row.Scan(
+		&pref.ProjectID,
+		&displayName,
+		&hidden,
+		&composeFiles,
+		&profiles,
+		&envFiles,
+	); err != nil {
+		return nil, err
+	}
+
+	pref.DisplayName = displayName.String
+	pref.Hidden = hidden == 1
+	if composeFiles.Valid {
+		if err := json.Unmarshal([]byte(composeFiles.String), &pref.ComposeFiles); err != nil {
+			return nil, fmt.Errorf("decoding compose files of %s: %w", pref.ProjectID, err)
+		}
+	}
+	if profiles.Valid {
+		if err := json.Unmarshal([]byte(profiles.String), &pref.Profiles); err != nil {
+			return nil, fmt.Errorf("decoding profiles of %s: %w", pref.ProjectID, err)
+		}
+	}
+	if envFiles.Valid {
+		if err := json.Unmarshal([]byte(envFiles.String), &pref.EnvFiles); err != nil {
+			return nil, fmt.Errorf("decoding env files of %s: %w", pref.ProjectID, err)
+		}
+	}
+
+	return &pref, nil
+}
+
 func (s *Store) SetDisplayName(projectID, displayName string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -154,6 +315,47 @@ func (s *Store) SetHidden(projectID string, hidden bool) error {
 	return err
 }
 
+// SetComposeFiles stores the compose files chosen for a project, by name.
+// An empty list clears the choice.
+func (s *Store) SetComposeFiles(projectID string, files []string) error {
+	return s.setListPreference(projectID, "compose_files", files)
+}
+
+// SetProfiles stores the compose profiles activated for a project. An
+// empty list clears the choice.
+func (s *Store) SetProfiles(projectID string, profiles []string) error {
+	return s.setListPreference(projectID, "profiles", profiles)
+}
+
+// SetEnvFiles stores the env files chosen for a project, by name. An
+// empty list clears the choice.
+func (s *Store) SetEnvFiles(projectID string, files []string) error {
+	return s.setListPreference(projectID, "env_files", files)
+}
+
+// setListPreference stores a list preference as JSON, or NULL when empty.
+func (s *Store) setListPreference(projectID, column string, values []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var encoded sql.NullString
+	if len(values) > 0 {
+		data, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		encoded = sql.NullString{String: string(data), Valid: true}
+	}
+
+	_, err := s.db.Exec(fmt.Sprintf(`
+		INSERT INTO project_preferences (project_id, display_name, hidden, %[1]s)
+		VALUES (?, NULL, 0, ?)
+		ON CONFLICT(project_id) DO UPDATE SET %[1]s = excluded.%[1]s
+	`, column), projectID, encoded)
+
+	return err
+}
+
 func (s *Store) DeletePreference(projectID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/carterperez-dev/holophyly/internal/auth"
 )
 
 const (
@@ -24,18 +26,30 @@ const (
 type Client struct {
 	hub           *Hub
 	conn          *websocket.Conn
+	logs          *LogStreamer
+	principal     *auth.Principal
 	send          chan []byte
+	done          chan struct{}
 	subscriptions map[string]bool
 	logger        *slog.Logger
 	mu            sync.RWMutex
 }
 
 // NewClient creates a WebSocket client.
-func NewClient(hub *Hub, conn *websocket.Conn, logger *slog.Logger) *Client {
+func NewClient(
+	hub *Hub,
+	conn *websocket.Conn,
+	logs *LogStreamer,
+	principal *auth.Principal,
+	logger *slog.Logger,
+) *Client {
 	return &Client{
 		hub:           hub,
 		conn:          conn,
+		logs:          logs,
+		principal:     principal,
 		send:          make(chan []byte, 256),
+		done:          make(chan struct{}),
 		subscriptions: make(map[string]bool),
 		logger:        logger,
 	}
@@ -44,6 +58,8 @@ func NewClient(hub *Hub, conn *websocket.Conn, logger *slog.Logger) *Client {
 // ReadPump handles incoming messages from the WebSocket connection.
 func (c *Client) ReadPump() {
 	defer func() {
+		close(c.done)
+		c.logs.RemoveClient(c)
 		c.hub.Unregister(c)
 		_ = c.conn.Close()
 	}()
@@ -162,5 +178,31 @@ func (c *Client) handleMessage(data []byte) {
 		if projectID, ok := msg.Payload.(string); ok {
 			c.Unsubscribe(projectID)
 		}
+
+	case MsgSubscribeLogs:
+		var sub LogSubscription
+		if err := decodePayload(msg.Payload, &sub); err != nil {
+			c.logger.Error("invalid subscribe_logs payload", "error", err)
+			return
+		}
+		// Backlog loading talks to docker; keep the read loop responsive.
+		go c.logs.Subscribe(c, sub)
+
+	case MsgUnsubLogs:
+		var sub LogSubscription
+		if err := decodePayload(msg.Payload, &sub); err != nil {
+			c.logger.Error("invalid unsubscribe_logs payload", "error", err)
+			return
+		}
+		c.logs.Unsubscribe(c, sub)
+	}
+}
+
+// decodePayload converts a generically decoded payload into a typed struct.
+func decodePayload(payload, v any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
 	}
+	return json.Unmarshal(data, v)
 }
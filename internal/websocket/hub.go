@@ -11,6 +11,8 @@ import (
 	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/auth"
 )
 
 type Hub struct {
@@ -117,6 +119,49 @@ func (h *Hub) BroadcastToSubscribers(projectID string, msg *Message) {
 	}
 }
 
+// BroadcastToPermitted sends a message to clients whose principal holds perm.
+func (h *Hub) BroadcastToPermitted(perm auth.Permission, msg *Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("failed to marshal message", "error", err)
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.clients {
+		if client.principal.Can(perm) {
+			select {
+			case client.send <- data:
+			default:
+			}
+		}
+	}
+}
+
+// SendToClient sends a message to a single client if it is still connected.
+// Slow clients drop the message rather than blocking the sender.
+func (h *Hub) SendToClient(client *Client, msg *Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("failed to marshal message", "error", err)
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if !h.clients[client] {
+		return
+	}
+
+	select {
+	case client.send <- data:
+	default:
+	}
+}
+
 // ClientCount returns the number of connected clients.
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
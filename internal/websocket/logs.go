@@ -0,0 +1,473 @@
+/*
+AngelaMos | 2026
+logs.go
+*/
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/holophyly/internal/docker"
+	"github.com/carterperez-dev/holophyly/internal/model"
+	"github.com/carterperez-dev/holophyly/internal/project"
+)
+
+const backlogTimeout = 10 * time.Second
+
+type LogSubscription struct {
+	ContainerID string `json:"container_id,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+	Tail        string `json:"tail,omitempty"`
+	Since       string `json:"since,omitempty"`
+	Stdout      *bool  `json:"stdout,omitempty"`
+	Stderr      *bool  `json:"stderr,omitempty"`
+}
+
+type LogLinePayload struct {
+	ContainerID   string    `json:"container_id"`
+	ContainerName string    `json:"container_name,omitempty"`
+	ServiceName   string    `json:"service_name,omitempty"`
+	Stream        string    `json:"stream,omitempty"`
+	Line          string    `json:"line,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+	EOF           bool      `json:"eof,omitempty"`
+}
+
+type logSubscriber struct {
+	projectID string
+	stdout    bool
+	stderr    bool
+	// catchingUp holds live lines in pending while the lines logged
+	// since the subscriber's backlog are fetched.
+	catchingUp bool
+	pending    []docker.LogLine
+}
+
+// subscriberKey identifies one subscription of a client to a container:
+// a direct one, with no projectID, or one through a project.
+type subscriberKey struct {
+	client    *Client
+	projectID string
+}
+
+func (s *logSubscriber) wants(stream string) bool {
+	if stream == docker.StreamStderr {
+		return s.stderr
+	}
+	return s.stdout
+}
+
+type logStream struct {
+	containerName string
+	serviceName   string
+	cancel        context.CancelFunc
+	subscribers   map[subscriberKey]*logSubscriber
+}
+
+// LogStreamer fans out docker log follow streams to WebSocket clients.
+// One docker stream runs per container regardless of how many clients
+// watch it, and is torn down when the last subscriber leaves.
+type LogStreamer struct {
+	hub     *Hub
+	manager *project.Manager
+	logger  *slog.Logger
+	streams map[string]*logStream
+	mu      sync.Mutex
+}
+
+// NewLogStreamer creates a log streamer backed by the project manager.
+func NewLogStreamer(
+	hub *Hub,
+	manager *project.Manager,
+	logger *slog.Logger,
+) *LogStreamer {
+	return &LogStreamer{
+		hub:     hub,
+		manager: manager,
+		logger:  logger,
+		streams: make(map[string]*logStream),
+	}
+}
+
+// Subscribe attaches a client to the logs of a container, or of every
+// container in a project. The requested backlog is sent first, then live
+// lines as they arrive. Project subscriptions cover the containers the
+// project has at subscribe time and are kept apart from direct ones, each
+// with its own stream filter.
+func (l *LogStreamer) Subscribe(client *Client, sub LogSubscription) {
+	if l == nil || l.manager == nil {
+		return
+	}
+
+	containers, err := l.resolveContainers(sub)
+	if err != nil {
+		l.hub.SendToClient(client, &Message{
+			Type:      MsgError,
+			ProjectID: sub.ProjectID,
+			Payload:   err.Error(),
+			Timestamp: time.Now().Unix(),
+		})
+		return
+	}
+
+	subscriber := &logSubscriber{
+		projectID: sub.ProjectID,
+		stdout:    sub.Stdout == nil || *sub.Stdout,
+		stderr:    sub.Stderr == nil || *sub.Stderr,
+	}
+
+	key := subscriberKey{client: client}
+	if sub.ContainerID == "" {
+		key.projectID = sub.ProjectID
+	}
+
+	tail := sub.Tail
+	if tail == "" {
+		tail = "50"
+	}
+
+	for _, ctr := range containers {
+		// Each container's stream gets its own copy to track catching up.
+		subscriber := *subscriber
+		since := l.sendBacklog(client, ctr, &subscriber, tail, sub.Since)
+		l.attach(key, ctr, &subscriber, since)
+	}
+}
+
+// Unsubscribe ends a client's direct subscription to a container, or its
+// subscription to a project.
+func (l *LogStreamer) Unsubscribe(client *Client, sub LogSubscription) {
+	if l == nil {
+		return
+	}
+
+	target := ""
+	if sub.ContainerID != "" {
+		target = l.containerID(sub.ContainerID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for containerID, stream := range l.streams {
+		for key := range stream.subscribers {
+			if key.client != client {
+				continue
+			}
+
+			matches := (target != "" && containerID == target &&
+				key.projectID == "") ||
+				(sub.ProjectID != "" && key.projectID == sub.ProjectID)
+			if matches {
+				l.detachLocked(key, containerID, stream)
+			}
+		}
+	}
+}
+
+// RemoveClient drops every log subscription held by a client.
+func (l *LogStreamer) RemoveClient(client *Client) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for containerID, stream := range l.streams {
+		for key := range stream.subscribers {
+			if key.client == client {
+				l.detachLocked(key, containerID, stream)
+			}
+		}
+	}
+}
+
+func (l *LogStreamer) resolveContainers(
+	sub LogSubscription,
+) ([]model.Container, error) {
+	if sub.ContainerID != "" {
+		if ctr, ok := l.manager.FindContainer(sub.ContainerID); ok {
+			return []model.Container{ctr}, nil
+		}
+		return []model.Container{{ID: l.containerID(sub.ContainerID)}}, nil
+	}
+
+	if sub.ProjectID == "" {
+		return nil, errors.New(
+			"subscribe_logs requires container_id or project_id",
+		)
+	}
+
+	proj, err := l.manager.GetProject(sub.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return proj.Containers, nil
+}
+
+// containerID expands a short container ID to the full ID that streams
+// are keyed by, so subscribe and unsubscribe agree on it. IDs that cannot
+// be resolved are returned as given.
+func (l *LogStreamer) containerID(id string) string {
+	if ctr, ok := l.manager.FindContainer(id); ok {
+		return ctr.ID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backlogTimeout)
+	defer cancel()
+
+	if ctr, err := l.manager.InspectContainer(ctx, id, ""); err == nil {
+		return ctr.ID
+	}
+	return id
+}
+
+// sendBacklog sends a subscriber the requested backlog and returns where a
+// follow stream should start so no line is lost or repeated: just after
+// the last line sent, or when the backlog was fetched if it was empty.
+// It returns "" when no backlog was requested.
+func (l *LogStreamer) sendBacklog(
+	client *Client,
+	ctr model.Container,
+	subscriber *logSubscriber,
+	tail, since string,
+) string {
+	if tail == "0" && since == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backlogTimeout)
+	defer cancel()
+
+	fetchedAt := time.Now()
+	lines, err := l.manager.GetContainerLogLines(ctx, ctr.ID, docker.LogOptions{
+		Tail:  tail,
+		Since: since,
+	})
+	if err != nil {
+		l.logger.Debug("failed to load log backlog",
+			"container", ctr.ID,
+			"error", err,
+		)
+		return ""
+	}
+
+	next := fetchedAt
+	if len(lines) > 0 {
+		next = lines[len(lines)-1].Timestamp.Add(time.Nanosecond)
+	}
+
+	for _, line := range lines {
+		if subscriber.wants(line.Stream) {
+			l.hub.SendToClient(
+				client,
+				logMessage(subscriber.projectID, ctr.ID, ctr.Name,
+					ctr.ServiceName, line),
+			)
+		}
+	}
+
+	return fmt.Sprintf("%d.%09d", next.Unix(), next.Nanosecond())
+}
+
+// attach registers a subscriber and starts the docker follow stream for the
+// container if this is its first subscriber. The stream starts at since,
+// where the subscriber's backlog ended, or at the present if since is "".
+// A subscriber joining a running stream is caught up from since instead.
+func (l *LogStreamer) attach(
+	key subscriberKey,
+	ctr model.Container,
+	subscriber *logSubscriber,
+	since string,
+) {
+	l.mu.Lock()
+
+	// The client may have disconnected while its backlog was loading.
+	select {
+	case <-key.client.done:
+		l.mu.Unlock()
+		return
+	default:
+	}
+
+	if stream, ok := l.streams[ctr.ID]; ok {
+		subscriber.catchingUp = since != ""
+		stream.subscribers[key] = subscriber
+		l.mu.Unlock()
+
+		if since != "" {
+			l.catchUp(key, ctr, stream, subscriber, since)
+		}
+		return
+	}
+	defer l.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &logStream{
+		containerName: ctr.Name,
+		serviceName:   ctr.ServiceName,
+		cancel:        cancel,
+		subscribers:   map[subscriberKey]*logSubscriber{key: subscriber},
+	}
+	l.streams[ctr.ID] = stream
+
+	// Subscribers already received their own backlog; continue from its
+	// end so lines logged in between are not lost.
+	opts := docker.LogOptions{Tail: "0"}
+	if since != "" {
+		opts = docker.LogOptions{Tail: "all", Since: since}
+	}
+	lineCh, errCh := l.manager.StreamContainerLogs(ctx, ctr.ID, opts)
+
+	go l.pump(ctx, ctr.ID, stream, lineCh, errCh)
+}
+
+// catchUp sends a subscriber that joined a running stream the lines logged
+// since its backlog, then the live lines held meanwhile that came after
+// them.
+func (l *LogStreamer) catchUp(
+	key subscriberKey,
+	ctr model.Container,
+	stream *logStream,
+	subscriber *logSubscriber,
+	since string,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), backlogTimeout)
+	defer cancel()
+
+	lines, err := l.manager.GetContainerLogLines(ctx, ctr.ID, docker.LogOptions{
+		Tail:  "all",
+		Since: since,
+	})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if stream.subscribers[key] != subscriber {
+		return
+	}
+
+	if err != nil {
+		l.logger.Debug("failed to catch up log stream",
+			"container", ctr.ID,
+			"error", err,
+		)
+	}
+
+	var last time.Time
+	for _, line := range lines {
+		last = line.Timestamp
+		if subscriber.wants(line.Stream) {
+			l.hub.SendToClient(key.client, logMessage(subscriber.projectID,
+				ctr.ID, stream.containerName, stream.serviceName, line))
+		}
+	}
+	for _, line := range subscriber.pending {
+		if line.Timestamp.After(last) && subscriber.wants(line.Stream) {
+			l.hub.SendToClient(key.client, logMessage(subscriber.projectID,
+				ctr.ID, stream.containerName, stream.serviceName, line))
+		}
+	}
+
+	subscriber.catchingUp = false
+	subscriber.pending = nil
+}
+
+func (l *LogStreamer) pump(
+	ctx context.Context,
+	containerID string,
+	stream *logStream,
+	lineCh <-chan docker.LogLine,
+	errCh <-chan error,
+) {
+	for line := range lineCh {
+		l.mu.Lock()
+		for key, subscriber := range stream.subscribers {
+			if subscriber.catchingUp {
+				subscriber.pending = append(subscriber.pending, line)
+				continue
+			}
+			if subscriber.wants(line.Stream) {
+				l.hub.SendToClient(
+					key.client,
+					logMessage(subscriber.projectID, containerID,
+						stream.containerName, stream.serviceName, line),
+				)
+			}
+		}
+		l.mu.Unlock()
+	}
+
+	if err := <-errCh; err != nil {
+		l.logger.Debug("log stream ended",
+			"container", containerID,
+			"error", err,
+		)
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	// The container stopped or the stream broke: tell remaining subscribers
+	// and drop the stream so a later subscribe starts a fresh one.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, subscriber := range stream.subscribers {
+		l.hub.SendToClient(key.client, &Message{
+			Type:      MsgContainerLogs,
+			ProjectID: subscriber.projectID,
+			Payload: LogLinePayload{
+				ContainerID: containerID,
+				Timestamp:   time.Now(),
+				EOF:         true,
+			},
+			Timestamp: time.Now().Unix(),
+		})
+	}
+
+	if l.streams[containerID] == stream {
+		delete(l.streams, containerID)
+	}
+	stream.cancel()
+}
+
+func logMessage(
+	projectID, containerID, containerName, serviceName string,
+	line docker.LogLine,
+) *Message {
+	return &Message{
+		Type:      MsgContainerLogs,
+		ProjectID: projectID,
+		Payload: LogLinePayload{
+			ContainerID:   containerID,
+			ContainerName: containerName,
+			ServiceName:   serviceName,
+			Stream:        line.Stream,
+			Line:          line.Text,
+			Timestamp:     line.Timestamp,
+		},
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+func (l *LogStreamer) detachLocked(
+	key subscriberKey,
+	containerID string,
+	stream *logStream,
+) {
+	delete(stream.subscribers, key)
+	if len(stream.subscribers) == 0 {
+		stream.cancel()
+		delete(l.streams, containerID)
+	}
+}
@@ -8,9 +8,13 @@ package websocket
 import (
 	"log/slog"
 	"net/http"
+	"net/url"
+	"path"
+	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/carterperez-dev/holophyly/internal/auth"
 	"github.com/carterperez-dev/holophyly/internal/project"
 )
 
@@ -19,10 +23,16 @@ type MessageType string
 const (
 	MsgProjectList    MessageType = "project_list"
 	MsgProjectStatus  MessageType = "project_status"
+	MsgProjectRemoved MessageType = "project_removed"
 	MsgContainerStats MessageType = "container_stats"
 	MsgContainerLogs  MessageType = "container_logs"
 	MsgSubscribe      MessageType = "subscribe"
 	MsgUnsubscribe    MessageType = "unsubscribe"
+	MsgSubscribeLogs  MessageType = "subscribe_logs"
+	MsgUnsubLogs      MessageType = "unsubscribe_logs"
+	MsgAuditEvent     MessageType = "audit_event"
+	MsgJobUpdate      MessageType = "job_update"
+	MsgComposeOutput  MessageType = "compose_output"
 	MsgError          MessageType = "error"
 )
 
@@ -33,42 +43,95 @@ type Message struct {
 	Timestamp int64       `json:"timestamp"`
 }
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+// ComposeOutputPayload carries one line of output from a compose job.
+type ComposeOutputPayload struct {
+	JobID     string    `json:"job_id"`
+	Kind      string    `json:"kind"`
+	Stream    string    `json:"stream"`
+	Line      string    `json:"line"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 type HTTPHandler struct {
-	hub     *Hub
-	manager *project.Manager
-	logger  *slog.Logger
+	hub            *Hub
+	manager        *project.Manager
+	logs           *LogStreamer
+	allowedOrigins []string
+	upgrader       websocket.Upgrader
+	logger         *slog.Logger
 }
 
 // NewHTTPHandler creates an HTTP handler for WebSocket upgrades.
+// allowedOrigins uses the same wildcard patterns as the CORS config,
+// e.g. "http://localhost:*".
 func NewHTTPHandler(
 	hub *Hub,
 	manager *project.Manager,
+	allowedOrigins []string,
 	logger *slog.Logger,
 ) *HTTPHandler {
-	return &HTTPHandler{
-		hub:     hub,
-		manager: manager,
-		logger:  logger,
+	h := &HTTPHandler{
+		hub:            hub,
+		manager:        manager,
+		logs:           NewLogStreamer(hub, manager, logger),
+		allowedOrigins: allowedOrigins,
+		logger:         logger,
+	}
+
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+	}
+
+	return h
+}
+
+// checkOrigin allows same-origin and configured origins. Requests without
+// an Origin header come from non-browser clients, which cannot be used for
+// cross-site WebSocket hijacking.
+func (h *HTTPHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
 	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Host == r.Host {
+		return true
+	}
+
+	for _, pattern := range h.allowedOrigins {
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+		if matched, _ := path.Match(pattern, origin); matched {
+			return true
+		}
+	}
+
+	h.logger.Warn("rejected websocket origin", "origin", origin)
+	return false
 }
 
 // HandleWebSocket upgrades HTTP connections to WebSocket.
 func (h *HTTPHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		h.logger.Error("failed to upgrade websocket", "error", err)
 		return
 	}
 
-	client := NewClient(h.hub, conn, h.logger)
+	client := NewClient(
+		h.hub,
+		conn,
+		h.logs,
+		auth.FromContext(r.Context()),
+		h.logger,
+	)
 	h.hub.Register(client)
 
 	go client.WritePump()